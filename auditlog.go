@@ -1,5 +1,15 @@
 package disgord
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/andersfylling/disgord/internal/util"
+	"github.com/andersfylling/disgord/json"
+)
+
 type AuditLogEvt uint
 
 // Audit-log event types
@@ -108,6 +118,17 @@ type AuditLog struct {
 var _ Copier = (*AuditLog)(nil)
 var _ DeepCopier = (*AuditLog)(nil)
 
+// User returns the user with the given id from the users referenced by the audit log entries, or nil.
+// Unlike the cache, the audit log holds users that are no longer in the guild.
+func (l *AuditLog) User(id Snowflake) *User {
+	for _, user := range l.Users {
+		if user.ID == id {
+			return user
+		}
+	}
+	return nil
+}
+
 func (l *AuditLog) Bans() (bans []*PartialBan) {
 	for _, e := range l.AuditLogEntries {
 		if e.Event == AuditLogEvtMemberBanAdd {
@@ -159,6 +180,193 @@ type AuditLogChanges struct {
 var _ Copier = (*AuditLogChanges)(nil)
 var _ DeepCopier = (*AuditLogChanges)(nil)
 
+// auditLogChangeFactories creates the typed value of a change key. Keys that are not listed are
+// decoded as they are given by the json package.
+var auditLogChangeFactories = map[AuditLogChange]func() interface{}{}
+
+func init() {
+	stringKeys := []AuditLogChange{
+		AuditLogChangeName, AuditLogChangeIconHash, AuditLogChangeSplashHash, AuditLogChangeRegion,
+		AuditLogChangeVanityURLCode, AuditLogChangeTopic, AuditLogChangeCode, AuditLogChangeNick,
+		AuditLogChangeAvatarHash,
+	}
+	snowflakeKeys := []AuditLogChange{
+		AuditLogChangeOwnerID, AuditLogChangeAFKChannelID, AuditLogChangeWidgetChannelID,
+		AuditLogChangeApplicationID, AuditLogChangeChannelID, AuditLogChangeInviterID, AuditLogChangeID,
+	}
+	intKeys := []AuditLogChange{
+		AuditLogChangeAFKTimeout, AuditLogChangeMFALevel, AuditLogChangeVerificationLevel,
+		AuditLogChangeExplicitContentFilter, AuditLogChangeDefaultMessageNotifications,
+		AuditLogChangePruneDeleteDays, AuditLogChangePosition, AuditLogChangeBitrate, AuditLogChangeColor,
+		AuditLogChangeMaxUses, AuditLogChangeUses, AuditLogChangeMaxAge,
+	}
+	boolKeys := []AuditLogChange{
+		AuditLogChangeWidgetEnabled, AuditLogChangeNSFW, AuditLogChangeHoist, AuditLogChangeMentionable,
+		AuditLogChangeTemporary, AuditLogChangeDeaf, AuditLogChangeMute,
+	}
+	permissionKeys := []AuditLogChange{
+		AuditLogChangePermissions, AuditLogChangeAllow, AuditLogChangeDeny,
+	}
+
+	for _, key := range stringKeys {
+		auditLogChangeFactories[key] = func() interface{} { return new(string) }
+	}
+	for _, key := range snowflakeKeys {
+		auditLogChangeFactories[key] = func() interface{} { return new(Snowflake) }
+	}
+	for _, key := range intKeys {
+		auditLogChangeFactories[key] = func() interface{} { return new(int) }
+	}
+	for _, key := range boolKeys {
+		auditLogChangeFactories[key] = func() interface{} { return new(bool) }
+	}
+	for _, key := range permissionKeys {
+		auditLogChangeFactories[key] = func() interface{} { return new(PermissionBit) }
+	}
+	auditLogChangeFactories[AuditLogChangeAdd] = func() interface{} { return &[]*Role{} }
+	auditLogChangeFactories[AuditLogChangeRemove] = func() interface{} { return &[]*Role{} }
+	auditLogChangeFactories[AuditLogChangePermissionOverwrites] = func() interface{} { return &[]*PermissionOverwrite{} }
+}
+
+func decodeAuditLogChangeValue(key AuditLogChange, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	factory, ok := auditLogChangeFactories[key]
+	if !ok {
+		// the type key is either a channel type or a string
+		if f, isNumber := v.(float64); isNumber && key == AuditLogChangeType {
+			return int(f), nil
+		}
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	value := factory()
+	if err = json.Unmarshal(data, value); err != nil {
+		return nil, fmt.Errorf("unable to decode audit log change %s: %w", key, err)
+	}
+	return reflect.ValueOf(value).Elem().Interface(), nil
+}
+
+// Decode returns the old and new value as the type described by the change key. Names and hashes are
+// given as string, ids as Snowflake, numbers as int, booleans as bool, permissions as PermissionBit,
+// $add and $remove as []*Role and overwrites as []*PermissionOverwrite. A value that is not given is nil.
+//
+//  old, new, err := change.Decode()
+//  if change.Key == string(disgord.AuditLogChangeNick) {
+//  	nick := new.(string)
+//  }
+func (a *AuditLogChanges) Decode() (oldValue, newValue interface{}, err error) {
+	key := AuditLogChange(a.Key)
+	if oldValue, err = decodeAuditLogChangeValue(key, a.OldValue); err != nil {
+		return nil, nil, err
+	}
+	if newValue, err = decodeAuditLogChangeValue(key, a.NewValue); err != nil {
+		return nil, nil, err
+	}
+	return oldValue, newValue, nil
+}
+
+// AuditLogChangeValues holds the typed values of a change, see AuditLogChanges.Decode.
+type AuditLogChangeValues struct {
+	Old interface{}
+	New interface{}
+}
+
+// Change returns the change with the given key, or nil if the key was not changed.
+func (e *AuditLogEntry) Change(key AuditLogChange) *AuditLogChanges {
+	for _, change := range e.Changes {
+		if change.Key == string(key) {
+			return change
+		}
+	}
+	return nil
+}
+
+// DecodeChanges returns the typed values of every change, keyed by the change key.
+func (e *AuditLogEntry) DecodeChanges() (map[AuditLogChange]*AuditLogChangeValues, error) {
+	changes := make(map[AuditLogChange]*AuditLogChangeValues, len(e.Changes))
+	for _, change := range e.Changes {
+		oldValue, newValue, err := change.Decode()
+		if err != nil {
+			return nil, err
+		}
+		changes[AuditLogChange(change.Key)] = &AuditLogChangeValues{Old: oldValue, New: newValue}
+	}
+	return changes, nil
+}
+
+func (e AuditLogEvt) targetsUser() bool {
+	return (e >= AuditLogEvtMemberKick && e <= AuditLogEvtBotAdd && e != AuditLogEvtMemberPrune) ||
+		e == AuditLogEvtMessageDelete
+}
+
+func (e AuditLogEvt) targetsChannel() bool {
+	return e >= AuditLogEvtChannelCreate && e <= AuditLogEvtOverwriteDelete
+}
+
+func (e AuditLogEvt) targetsRole() bool {
+	return e >= AuditLogEvtRoleCreate && e <= AuditLogEvtRoleDelete
+}
+
+func (e AuditLogEvt) targetsEmoji() bool {
+	return e >= AuditLogEvtEmojiCreate && e <= AuditLogEvtEmojiDelete
+}
+
+// TargetUser returns the targeted user from the cache, for member, bot and message events. For
+// message deletions, the user is the author of the deleted messages.
+func (e *AuditLogEntry) TargetUser(cache CacheGetter) (*User, error) {
+	if !e.Event.targetsUser() || e.TargetID.IsZero() {
+		return nil, errors.New("audit log entry does not target a user")
+	}
+	return cache.GetUser(e.TargetID)
+}
+
+// TargetChannel returns the targeted channel from the cache, for channel and overwrite events.
+// Note that the channel is no longer cached once it has been deleted.
+func (e *AuditLogEntry) TargetChannel(cache CacheGetter) (*Channel, error) {
+	if !e.Event.targetsChannel() || e.TargetID.IsZero() {
+		return nil, errors.New("audit log entry does not target a channel")
+	}
+	return cache.GetChannel(e.TargetID)
+}
+
+// TargetRole returns the targeted role from the cache, for role events in the given guild.
+// Note that the role is no longer cached once it has been deleted.
+func (e *AuditLogEntry) TargetRole(cache CacheGetter, guildID Snowflake) (*Role, error) {
+	if !e.Event.targetsRole() || e.TargetID.IsZero() {
+		return nil, errors.New("audit log entry does not target a role")
+	}
+	roles, err := cache.GetGuildRoles(guildID)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if role.ID == e.TargetID {
+			return role, nil
+		}
+	}
+	return nil, CacheMissErr
+}
+
+// TargetEmoji returns the targeted emoji from the cache, for emoji events in the given guild.
+func (e *AuditLogEntry) TargetEmoji(cache CacheGetter, guildID Snowflake) (*Emoji, error) {
+	if !e.Event.targetsEmoji() || e.TargetID.IsZero() {
+		return nil, errors.New("audit log entry does not target an emoji")
+	}
+	return cache.GetGuildEmoji(guildID, e.TargetID)
+}
+
+// User returns the user that made the changes from the cache.
+func (e *AuditLogEntry) User(cache CacheGetter) (*User, error) {
+	return cache.GetUser(e.UserID)
+}
+
 // auditLogFactory temporary until flyweight is implemented
 func auditLogFactory() interface{} {
 	return &AuditLog{}
@@ -172,8 +380,52 @@ func auditLogFactory() interface{} {
 
 // guildAuditLogsBuilder for building the GetGuildAuditLogs request.
 // TODO: support caching of audit log entries. So we only fetch those we don't have.
-//generate-rest-params: user_id:Snowflake, action_type:uint, before:Snowflake, limit:int,
+//generate-rest-params: user_id:Snowflake, action_type:uint, before:Snowflake, after:Snowflake, limit:int,
 //generate-rest-basic-execute: log:*AuditLog,
 type guildAuditLogsBuilder struct {
 	r RESTBuilder
+
+	// guild requests the next pages when iterating
+	guild guildQueryBuilder
+}
+
+// snowflakeAt returns the lowest snowflake created at the given time.
+// A zero snowflake is returned for times before the Discord epoch.
+func snowflakeAt(t time.Time) Snowflake {
+	epoch := time.Unix(0, int64(util.EpochDiscord)*int64(time.Millisecond))
+	if !t.After(epoch) {
+		return 0
+	}
+	return Snowflake(uint64(t.Sub(epoch)/time.Millisecond) << 22)
+}
+
+// SetTimeRange only returns entries created within the given time range, where a zero time leaves
+// that end of the range open.
+func (b *guildAuditLogsBuilder) SetTimeRange(since, until time.Time) GuildAuditLogsBuilder {
+	if after := snowflakeAt(since); !after.IsZero() {
+		b.SetAfter(after)
+	}
+	if before := snowflakeAt(until); !before.IsZero() {
+		b.SetBefore(before)
+	}
+	return b
+}
+
+// Iterate returns an iterator over every entry matching the filters of the builder, across as many
+// pages as needed. The limit is ignored, as pages of 100 entries are requested.
+func (b *guildAuditLogsBuilder) Iterate() *AuditLogIterator {
+	params := &IterateAuditLogsParams{}
+	if userID, ok := b.r.urlParams["user_id"].(Snowflake); ok {
+		params.UserID = userID
+	}
+	if actionType, ok := b.r.urlParams["action_type"].(uint); ok {
+		params.ActionType = AuditLogEvt(actionType)
+	}
+	if before, ok := b.r.urlParams["before"].(Snowflake); ok {
+		params.Before = before
+	}
+	if after, ok := b.r.urlParams["after"].(Snowflake); ok {
+		params.After = after
+	}
+	return b.guild.IterateAuditLogs(params, b.r.flags...)
 }
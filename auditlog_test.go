@@ -7,9 +7,11 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/andersfylling/disgord/internal/endpoint"
 	"github.com/andersfylling/disgord/internal/httd"
+	"github.com/andersfylling/disgord/json"
 )
 
 func TestAuditLog_InterfaceImplementations(t *testing.T) {
@@ -180,3 +182,151 @@ func TestGuildAuditLogs(t *testing.T) {
 		// TODO: implement ErrREST check
 	})
 }
+
+func TestAuditLogChanges_Decode(t *testing.T) {
+	data := []byte(`{
+		"id": "3", "action_type": 25, "target_id": "4", "user_id": "5",
+		"changes": [
+			{"key": "nick", "old_value": "a", "new_value": "b"},
+			{"key": "$add", "new_value": [{"id": "6", "name": "mod"}]},
+			{"key": "permissions", "old_value": "8", "new_value": "2048"},
+			{"key": "mute", "new_value": true},
+			{"key": "owner_id", "new_value": "7"},
+			{"key": "type", "new_value": 2},
+			{"key": "permission_overwrites", "new_value": [{"id": "8", "type": 1, "allow": "1024", "deny": "0"}]}
+		]
+	}`)
+	entry := &AuditLogEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := entry.DecodeChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes[AuditLogChangeNick].Old != "a" || changes[AuditLogChangeNick].New != "b" {
+		t.Errorf("incorrect nick change, got %+v", changes[AuditLogChangeNick])
+	}
+	if roles, ok := changes[AuditLogChangeAdd].New.([]*Role); !ok || len(roles) != 1 || roles[0].ID != 6 || roles[0].Name != "mod" {
+		t.Errorf("incorrect $add change, got %+v", changes[AuditLogChangeAdd].New)
+	}
+	if changes[AuditLogChangeAdd].Old != nil {
+		t.Errorf("expected the old value to be nil, got %+v", changes[AuditLogChangeAdd].Old)
+	}
+	if changes[AuditLogChangePermissions].Old != PermissionBit(8) || changes[AuditLogChangePermissions].New != PermissionBit(2048) {
+		t.Errorf("incorrect permissions change, got %+v", changes[AuditLogChangePermissions])
+	}
+	if changes[AuditLogChangeMute].New != true {
+		t.Errorf("incorrect mute change, got %+v", changes[AuditLogChangeMute])
+	}
+	if changes[AuditLogChangeOwnerID].New != Snowflake(7) {
+		t.Errorf("incorrect owner change, got %+v", changes[AuditLogChangeOwnerID])
+	}
+	if changes[AuditLogChangeType].New != 2 {
+		t.Errorf("incorrect type change, got %+v", changes[AuditLogChangeType])
+	}
+	if overwrites, ok := changes[AuditLogChangePermissionOverwrites].New.([]*PermissionOverwrite); !ok || len(overwrites) != 1 || overwrites[0].Allow != 1024 {
+		t.Errorf("incorrect overwrites change, got %+v", changes[AuditLogChangePermissionOverwrites].New)
+	}
+
+	if entry.Change(AuditLogChangeNick) == nil || entry.Change(AuditLogChangeTopic) != nil {
+		t.Error("incorrect change lookup")
+	}
+}
+
+type auditLogCacheMock struct {
+	CacheNop
+	users map[Snowflake]*User
+	roles []*Role
+}
+
+func (c *auditLogCacheMock) GetUser(id Snowflake) (*User, error) {
+	if user, ok := c.users[id]; ok {
+		return user, nil
+	}
+	return nil, CacheMissErr
+}
+
+func (c *auditLogCacheMock) GetGuildRoles(_ Snowflake) ([]*Role, error) {
+	return c.roles, nil
+}
+
+func TestAuditLogEntry_Target(t *testing.T) {
+	cache := &auditLogCacheMock{
+		users: map[Snowflake]*User{4: {ID: 4}, 5: {ID: 5}},
+		roles: []*Role{{ID: 9}},
+	}
+
+	ban := &AuditLogEntry{Event: AuditLogEvtMemberBanAdd, TargetID: 4, UserID: 5}
+	if user, err := ban.TargetUser(cache); err != nil || user.ID != 4 {
+		t.Errorf("expected user 4, got %+v, %v", user, err)
+	}
+	if user, err := ban.User(cache); err != nil || user.ID != 5 {
+		t.Errorf("expected user 5, got %+v, %v", user, err)
+	}
+	if _, err := ban.TargetRole(cache, 1); err == nil {
+		t.Error("a ban does not target a role")
+	}
+
+	role := &AuditLogEntry{Event: AuditLogEvtRoleUpdate, TargetID: 9}
+	if r, err := role.TargetRole(cache, 1); err != nil || r.ID != 9 {
+		t.Errorf("expected role 9, got %+v, %v", r, err)
+	}
+	if _, err := role.TargetUser(cache); err == nil {
+		t.Error("a role update does not target a user")
+	}
+}
+
+func TestGuildAuditLogsBuilder_SetTimeRange(t *testing.T) {
+	builder := &guildAuditLogsBuilder{}
+	builder.r.setup(nil, nil, nil)
+
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	builder.SetTimeRange(since, time.Time{})
+	after, ok := builder.r.urlParams["after"].(Snowflake)
+	if !ok || !after.Date().Equal(since) {
+		t.Errorf("expected after to be created at %s, got %s", since, after.Date())
+	}
+	if _, ok = builder.r.urlParams["before"]; ok {
+		t.Error("the range should be open ended")
+	}
+}
+
+func TestGuildAuditLogsBuilder_Iterate(t *testing.T) {
+	var requests int
+	client := newPagingClient(t, func(req *http.Request) interface{} {
+		requests++
+		if req.URL.Query().Get("action_type") != "22" || req.URL.Query().Get("after") != "" {
+			t.Errorf("unexpected query %s", req.URL.RawQuery)
+		}
+		before, limit := queryInt(req, "before"), queryInt(req, "limit")
+		log := &AuditLog{}
+		for id := before - 1; id > 0 && len(log.AuditLogEntries) < limit; id-- {
+			log.AuditLogEntries = append(log.AuditLogEntries, &AuditLogEntry{ID: Snowflake(id)})
+		}
+		return log
+	})
+
+	ctx := context.Background()
+	it := client.Guild(1).GetAuditLogs().SetActionType(uint(AuditLogEvtMemberBanAdd)).SetBefore(300).SetAfter(50).Iterate()
+	var count int
+	for {
+		entry, err := it.Next(ctx)
+		if errors.Is(err, ErrIteratorDone) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if entry.ID <= 50 || entry.ID >= 300 {
+			t.Fatalf("entry %d is outside the range", entry.ID)
+		}
+		count++
+	}
+	if count != 249 {
+		t.Errorf("expected 249 entries, got %d", count)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+}
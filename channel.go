@@ -301,6 +301,10 @@ type ChannelQueryBuilder interface {
 	// will only be counted once.
 	DeleteMessages(params *DeleteMessagesParams, flags ...Flag) error
 
+	// Purge deletes the messages matching the params, by reading the channel history from the newest message.
+	// Messages younger than 14 days are bulk deleted, while older messages are deleted one by one.
+	Purge(params *PurgeParams, flags ...Flag) (*PurgeProgress, error)
+
 	// GetMessages Returns the messages for a channel. If operating on a guild channel, this endpoint requires
 	// the 'VIEW_CHANNEL' permission to be present on the current user. If the current user is missing
 	// the 'READ_MESSAGE_HISTORY' permission in the channel then this will return no messages
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"sync"
@@ -17,6 +18,7 @@ import (
 	"github.com/andersfylling/disgord/internal/constant"
 
 	"github.com/andersfylling/disgord/internal/httd"
+	"github.com/andersfylling/disgord/json"
 )
 
 var DefaultHttpClient = &http.Client{}
@@ -112,11 +114,25 @@ func createClient(ctx context.Context, conf *Config) (c *Client, err error) {
 	if _, ok := uniqueEventNames[EvtReady]; ok && conf.LoadMembersQuietly {
 		return nil, fmt.Errorf("you can not reject the READY event when LoadMembersQuietly is set to true")
 	}
+	if _, ok := uniqueEventNames[EvtGuildMembersChunk]; ok && conf.LoadMembersQuietly {
+		return nil, fmt.Errorf("you can not reject the GUILD_MEMBERS_CHUNK event when LoadMembersQuietly is set to true")
+	}
 	conf.RejectEvents = make([]string, 0, len(uniqueEventNames))
 	for eventName, _ := range uniqueEventNames {
 		conf.RejectEvents = append(conf.RejectEvents, eventName)
 	}
 
+	// every 429 is logged, as too many of them results in a temporary ban
+	log, onRESTRateLimit := conf.Logger, conf.OnRESTRateLimit
+	onRateLimit := func(evt *RESTRateLimitEvent) {
+		if evt.Limited() {
+			log.Error(fmt.Sprintf("rate limited on %s with scope %s, retry after %s", evt.HashedEndpoint, evt.Scope, evt.RetryAfter))
+		}
+		if onRESTRateLimit != nil {
+			onRESTRateLimit(evt)
+		}
+	}
+
 	httdClient, err := httd.NewClient(&httd.Config{
 		APIVersion:                   constant.DiscordVersion,
 		BotToken:                     conf.BotToken,
@@ -126,6 +142,11 @@ func createClient(ctx context.Context, conf *Config) (c *Client, err error) {
 		HttpClient:                   conf.HTTPClient,
 		CancelRequestWhenRateLimited: conf.CancelRequestWhenRateLimited,
 		RESTBucketManager:            conf.RESTBucketManager,
+		RetryPolicy:                  conf.RESTRetryPolicy,
+		BaseURL:                      conf.RESTProxyURL,
+		Interceptors:                 conf.RESTInterceptors,
+		OnRateLimit:                  onRateLimit,
+		RateLimitDelayThreshold:      conf.RESTRateLimitDelayThreshold,
 	})
 	if err != nil {
 		return nil, err
@@ -157,6 +178,7 @@ func createClient(ctx context.Context, conf *Config) (c *Client, err error) {
 
 	// event dispatcher
 	dispatch := newDispatcher()
+	dispatch.onHandlerError = conf.OnHandlerError
 
 	// create a disgord Client/instance/session
 	c = &Client{
@@ -170,6 +192,11 @@ func createClient(ctx context.Context, conf *Config) (c *Client, err error) {
 		log:                 conf.Logger,
 		pool:                newPools(),
 		eventChan:           evtChan,
+		disgordEvents:       make(chan *gateway.Event, disgordEventBuffer),
+	}
+	go c.forwardDisgordEvents(c.disgordEvents, evtChan)
+	if conf.DispatchConfig.Mode != DispatchConcurrent {
+		c.dispatchPool = newDispatchPool(dispatch, conf.DispatchConfig)
 	}
 	c.handlers.c = c // parent reference
 	c.dispatcher.addSessionInstance(c)
@@ -193,6 +220,35 @@ func createClient(ctx context.Context, conf *Config) (c *Client, err error) {
 
 type ShardConfig = gateway.ShardConfig
 
+// GatewayRecorder records the gateway traffic of every shard. See ShardConfig.Recorder.
+type GatewayRecorder = gateway.Recorder
+
+// NewGatewayRecorder creates a stopped recorder that writes the recording to w.
+func NewGatewayRecorder(w io.Writer) *GatewayRecorder {
+	return gateway.NewRecorder(w)
+}
+
+// GatewayReplay replays a recording made by a GatewayRecorder. See ShardConfig.Replay.
+type GatewayReplay = gateway.Replay
+
+// NewGatewayReplay parses a recording. A speed of 1 keeps the original timing, while 0 replays
+// the packets without any delay.
+func NewGatewayReplay(recording io.Reader, speed float64) (*GatewayReplay, error) {
+	return gateway.NewReplay(recording, speed)
+}
+
+// ShardStatus is a snapshot of the connection state of a shard.
+type ShardStatus = gateway.ShardStatus
+type ShardState = gateway.ShardState
+
+const (
+	ShardStateDisconnected   = gateway.ShardStateDisconnected
+	ShardStateWaitingInQueue = gateway.ShardStateWaitingInQueue
+	ShardStateIdentifying    = gateway.ShardStateIdentifying
+	ShardStateResuming       = gateway.ShardStateResuming
+	ShardStateConnected      = gateway.ShardStateConnected
+)
+
 type HttpClientDoer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
@@ -244,14 +300,54 @@ type Config struct {
 
 	CancelRequestWhenRateLimited bool
 
+	// RESTRetryPolicy decides whether REST requests that failed due to a transport error or a server
+	// error are sent again. Requests are not retried when nil. See DefaultRESTRetryPolicy.
+	RESTRetryPolicy *RESTRetryPolicy
+
+	// RESTProxyURL sends every REST request to a rate limiting proxy instead of directly to Discord,
+	// eg. "http://localhost:8080". The proxy is shared by every process using the same bot token,
+	// see cmd/rest-proxy.
+	RESTProxyURL string
+
+	// RESTInterceptors wrap every REST request once it is allowed through the rate limits, and can be used
+	// for logging, metrics or tracing. They are called in order, where the first is the outermost.
+	RESTInterceptors []RESTInterceptor
+
+	// OnRESTRateLimit is called whenever Discord responds with http 429 Too Many Requests, and whenever a REST
+	// request waited longer than RESTRateLimitDelayThreshold for its rate limit bucket. 429 responses are
+	// also logged as errors. The callback is called before the request returns, so it should not block.
+	OnRESTRateLimit func(evt *RESTRateLimitEvent)
+
+	// RESTRateLimitDelayThreshold is how long a REST request can wait for its bucket before OnRESTRateLimit is
+	// called. Only 429 responses are reported when 0.
+	RESTRateLimitDelayThreshold time.Duration
+
 	// LoadMembersQuietly will start fetching members for all Guilds in the background.
-	// There is currently no proper way to detect when the loading is done nor if it
-	// finished successfully.
+	// Use OnMembersLoaded to detect when the loading is done and whether it finished successfully.
 	LoadMembersQuietly bool
 
+	// OnMembersLoaded is called when LoadMembersQuietly has received every member of the Guilds given
+	// in a Ready event, or when the loading failed. As there is one Ready event per shard session, this
+	// can be called several times.
+	OnMembersLoaded func(shardID uint, err error)
+
+	// VoiceJitterBuffer is the number of Opus frames that can be queued for sending on a voice connection,
+	// where every frame holds 20ms of audio. A larger buffer keeps the audio smooth when the sender is
+	// delayed, at the cost of latency. Defaults to 5 frames (100ms).
+	VoiceJitterBuffer int
+
+	// OnHandlerError is called when an event handler returns an error, or when a handler or middleware panics.
+	// The panic is recovered, such that the other handlers and events are not affected. The handlers after a
+	// panicking middleware are skipped. The errors are also logged.
+	OnHandlerError func(err *HandlerError)
+
 	// Presence will automatically be emitted to discord on start up
 	Presence *UpdateStatusPayload
 
+	// DispatchConfig decides whether the events of a guild or channel reach the handlers in order.
+	// By default every event is dispatched concurrently.
+	DispatchConfig DispatchConfig
+
 	// for cancellation
 	shutdownChan chan interface{}
 
@@ -268,6 +364,9 @@ type Config struct {
 	// ## You use these features on your own risk.
 	// ##
 	// ################################################
+
+	// RESTBucketManager holds the REST rate limits. Use NewDistributedRESTBucketManager to share the
+	// rate limits with other processes using the same bot token.
 	RESTBucketManager httd.RESTBucketManager
 
 	DisableCache bool
@@ -307,9 +406,15 @@ type Client struct {
 
 	handlers internalHandlers
 
+	// pending RequestGuildMembers commands awaiting their guild member chunks
+	memberRequests guildMembersRequests
+
 	// reactor demultiplexer for events
 	dispatcher *dispatcher
 
+	// dispatchPool keeps the events in order, unless the events are dispatched concurrently
+	dispatchPool *dispatchPool
+
 	// cancelRequestWhenRateLimited by default the Client waits until either the HTTPClient.timeout or
 	// the rate limit ends before closing a request channel. If activated, in stead, requests will
 	// instantly be denied, and the process ended with a rate limited error.
@@ -323,6 +428,9 @@ type Client struct {
 	shardManager gateway.ShardManager
 	eventChan    chan *gateway.Event
 
+	// disgordEvents holds the events created by Disgord until the reactor can take them
+	disgordEvents chan *gateway.Event
+
 	connectedGuilds      []Snowflake
 	connectedGuildsMutex sync.RWMutex
 
@@ -395,6 +503,103 @@ func (c *Client) HeartbeatLatencies() (latencies map[uint]time.Duration, err err
 	return c.shardManager.HeartbeatLatencies()
 }
 
+// ShardStatus returns the connection state of a local shard.
+func (c *Client) ShardStatus(shardID uint) (status ShardStatus, err error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.shardManager == nil {
+		return status, errors.New("you must connect before you can inspect the shards")
+	}
+
+	shard, err := c.shardManager.GetShard(shardID)
+	if err != nil {
+		return status, err
+	}
+
+	status = shard.Status()
+	status.GuildCount = c.guildCounts(c.shardManager.ShardCount())[shardID]
+	return status, nil
+}
+
+// Shards returns the connection state of every local shard, sorted by shard ID.
+func (c *Client) Shards() (statuses []ShardStatus, err error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.shardManager == nil {
+		return nil, errors.New("you must connect before you can inspect the shards")
+	}
+
+	statuses = c.shardManager.ShardStatuses()
+	guildCounts := c.guildCounts(c.shardManager.ShardCount())
+	for i := range statuses {
+		statuses[i].GuildCount = guildCounts[statuses[i].ShardID]
+	}
+	return statuses, nil
+}
+
+// disgordEventBuffer is the number of events created by Disgord that can wait for the reactor, before
+// more events are dropped.
+const disgordEventBuffer = 100
+
+// emitDisgordEvent passes an event created by Disgord to the reactor, as if it was received from the
+// shard of the given guild. It does not block, as the events are created on the goroutines that read the
+// voice connections.
+func (c *Client) emitDisgordEvent(name string, guildID Snowflake, evt interface{}) {
+	if c == nil || c.disgordEvents == nil {
+		return
+	}
+
+	data, err := json.Marshal(evt)
+	if err != nil {
+		c.log.Error(fmt.Errorf("unable to marshal %s: %w", name, err))
+		return
+	}
+
+	var shardID uint
+	c.mu.RLock()
+	if c.shardManager != nil {
+		shardID = ShardID(guildID, c.shardManager.ShardCount())
+	}
+	c.mu.RUnlock()
+
+	select {
+	case c.disgordEvents <- &gateway.Event{Name: name, Data: data, ShardID: shardID}:
+	default:
+		c.log.Error(fmt.Errorf("dropped %s as the events are not read", name))
+	}
+}
+
+// forwardDisgordEvents passes the events created by Disgord to the reactor, until the client shuts down.
+func (c *Client) forwardDisgordEvents(events <-chan *gateway.Event, eventChan chan<- *gateway.Event) {
+	for {
+		select {
+		case evt := <-events:
+			select {
+			case eventChan <- evt:
+			case <-c.shutdownChan:
+				return
+			}
+		case <-c.shutdownChan:
+			return
+		}
+	}
+}
+
+// guildCounts maps the connected guilds to their shards. shardID => number of guilds.
+func (c *Client) guildCounts(shardCount uint) map[uint]uint {
+	counts := make(map[uint]uint)
+	if shardCount == 0 {
+		return counts
+	}
+
+	c.connectedGuildsMutex.RLock()
+	defer c.connectedGuildsMutex.RUnlock()
+	for _, guildID := range c.connectedGuilds {
+		counts[gateway.GetShardForGuildID(guildID, shardCount)]++
+	}
+	return counts
+}
+
 // GetConnectedGuilds get a list over guild IDs that this Client is "connected to"; or have joined through the ws connection. This will always hold the different Guild IDs, while the GetGuilds or GetCurrentUserGuilds might be affected by cache configuration.
 func (c *Client) GetConnectedGuilds() []Snowflake {
 	c.connectedGuildsMutex.RLock()
@@ -424,6 +629,12 @@ func (c *Client) RESTRatelimitBuckets() (group map[string][]string) {
 	return c.req.BucketGrouping()
 }
 
+// RESTRatelimitState returns the current state of every REST rate limit bucket and the global rate limit.
+// An error is returned if a custom RESTBucketManager does not expose its state.
+func (c *Client) RESTRatelimitState() (*RESTRateLimitState, error) {
+	return c.req.RateLimitState()
+}
+
 // Cache returns the cacheLink manager for the session
 func (c *Client) Cache() Cache {
 	return c.cache
@@ -443,11 +654,22 @@ func (c *Client) setupConnectEnv() {
 	}
 	c.Gateway().GuildCreate(c.handlers.saveGuildID)
 	c.Gateway().GuildDelete(c.handlers.deleteGuildID)
+	c.Gateway().GuildMembersChunk(c.handlers.collectMembers)
 
 	// start demultiplexer which also trigger dispatching
 	go c.demultiplexer(c.dispatcher, c.eventChan)
 }
 
+// rejectsEvent checks if Discord has been asked to not send the given event
+func (c *Client) rejectsEvent(evt string) bool {
+	for _, rejected := range c.config.RejectEvents {
+		if rejected == evt {
+			return true
+		}
+	}
+	return false
+}
+
 type helperGatewayBotGetter struct {
 	c *Client
 }
@@ -509,10 +731,31 @@ func (ih *internalHandlers) loadMembers(_ Session, evt *Ready) {
 	for i := range evt.Guilds {
 		guildIDs[i] = evt.Guilds[i].ID
 	}
+	if len(guildIDs) == 0 {
+		if client.config.OnMembersLoaded != nil {
+			client.config.OnMembersLoaded(evt.ShardID, nil)
+		}
+		return
+	}
 
-	_, _ = client.Gateway().Dispatch(RequestGuildMembers, &RequestGuildMembersPayload{
-		GuildIDs: guildIDs,
-	})
+	// don't block other Ready handlers while waiting for the chunks. The request fails if Discord stops
+	// sending chunks, such that OnMembersLoaded is always called.
+	go func(shardID uint) {
+		_, err := client.Gateway().RequestGuildMembers(&RequestGuildMembersPayload{
+			GuildIDs: guildIDs,
+		})
+		if err != nil {
+			client.log.Error(fmt.Errorf("unable to load members for shard %d: %w", shardID, err))
+		}
+		if client.config.OnMembersLoaded != nil {
+			client.config.OnMembersLoaded(shardID, err)
+		}
+	}(evt.ShardID)
+}
+
+// collectMembers forwards guild member chunks to any pending RequestGuildMembers call
+func (ih *internalHandlers) collectMembers(_ Session, evt *GuildMembersChunk) {
+	ih.c.memberRequests.collect(evt)
 }
 
 //////////////////////////////////////////////////////
@@ -530,6 +773,7 @@ func (c *Client) EditInteractionResponse(ctx context.Context, interaction *Inter
 		Body:        message,
 		Ctx:         ctx,
 		ContentType: httd.ContentTypeJSON,
+		Priority:    httd.PriorityHigh,
 	}
 	_, _, err := c.req.Do(ctx, req)
 	return err
@@ -543,6 +787,7 @@ func (c *Client) SendInteractionResponse(ctx context.Context, interaction *Inter
 		Body:        data,
 		Ctx:         ctx,
 		ContentType: httd.ContentTypeJSON,
+		Priority:    httd.PriorityHigh,
 	}
 	_, _, err := c.req.Do(ctx, req)
 	return err
@@ -581,6 +826,9 @@ func (c *Client) newRESTRequest(conf *httd.Request, flags []Flag) *rest {
 	}
 	r.init()
 	r.flags = mergeFlags(flags)
+	if priority := r.flags.Priority(); priority != RESTPriorityNormal {
+		conf.Priority = priority
+	}
 
 	return r
 }
@@ -5,6 +5,7 @@ package disgord
 import (
 	"context"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
@@ -14,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/andersfylling/disgord/disgordtest"
 	"github.com/andersfylling/disgord/internal/logger"
 	"github.com/andersfylling/disgord/json"
 
@@ -285,6 +287,43 @@ func TestClient_On(t *testing.T) {
 	wg.Wait()
 }
 
+func TestClient_On_DisgordEvent(t *testing.T) {
+	c := New(Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		Cache:        &CacheNop{},
+	})
+	defer close(c.dispatcher.shutdown)
+
+	input := make(chan *gateway.Event)
+	go c.demultiplexer(c.dispatcher, input)
+
+	connected := make(chan *ShardConnected)
+	scaled := make(chan *ShardScaled)
+	c.Gateway().ShardConnectedChan(connected)
+	c.Gateway().ShardScaledChan(scaled)
+
+	input <- &gateway.Event{Name: EvtShardConnected, Data: []byte(`{}`), ShardID: 3}
+	select {
+	case evt := <-connected:
+		if evt.ShardID != 3 {
+			t.Errorf("expected shard id 3, got %d", evt.ShardID)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("ShardConnected was not dispatched")
+	}
+
+	input <- &gateway.Event{Name: EvtShardScaled, Data: []byte(`{"shard_count":4,"shard_ids":[2,3]}`), ShardID: 2}
+	select {
+	case evt := <-scaled:
+		if evt.ShardCount != 4 || len(evt.ShardIDs) != 2 {
+			t.Errorf("incorrect payload, got %+v", evt)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("ShardScaled was not dispatched")
+	}
+}
+
 func TestClient_On_Middleware(t *testing.T) {
 	c := New(Config{
 		BotToken:     "testing",
@@ -483,3 +522,135 @@ func TestInternalStateHandlers(t *testing.T) {
 		t.Errorf("Removing a connected guild should affect the internal state. Got %d, wants %d", len(c.GetConnectedGuilds()), 0)
 	}
 }
+
+func TestInternalHandlers_collectMembers(t *testing.T) {
+	c, err := NewClient(context.Background(), Config{
+		BotToken: "testing",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := c.memberRequests.add("test", []Snowflake{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.memberRequests.add("test", []Snowflake{3}); err == nil {
+		t.Error("expected an error when reusing the nonce of a pending request")
+	}
+
+	done := func() bool {
+		select {
+		case <-request.done:
+			return true
+		default:
+			return false
+		}
+	}
+
+	chunks := []*GuildMembersChunk{
+		{GuildID: 1, Nonce: "test", ChunkIndex: 1, ChunkCount: 2, Members: []*Member{{}}},
+		{GuildID: 2, Nonce: "test", ChunkIndex: 0, ChunkCount: 1, NotFound: []Snowflake{45, 46}},
+		{GuildID: 3, Nonce: "test", ChunkIndex: 0, ChunkCount: 1, Members: []*Member{{}}},
+		{GuildID: 1, Nonce: "other", ChunkIndex: 0, ChunkCount: 2, Members: []*Member{{}}},
+	}
+	for _, chunk := range chunks {
+		c.handlers.collectMembers(c, chunk)
+	}
+	if done() {
+		t.Fatal("the request should wait for every chunk of every guild")
+	}
+
+	c.handlers.collectMembers(c, &GuildMembersChunk{GuildID: 1, Nonce: "test", ChunkIndex: 0, ChunkCount: 2, Members: []*Member{{}}})
+	if !done() {
+		t.Fatal("the request should be completed")
+	}
+
+	response := request.response
+	if len(response.Members) != 2 {
+		t.Errorf("expected 2 members, got %d", len(response.Members))
+	}
+	if len(response.NotFound) != 2 || response.NotFound[0] != 45 || response.NotFound[1] != 46 {
+		t.Errorf("incorrect not found user ids, got %+v", response.NotFound)
+	}
+	if _, pending := c.memberRequests.pending["test"]; pending {
+		t.Error("completed requests should be removed")
+	}
+}
+
+func TestClient_FakeGateway(t *testing.T) {
+	gw := disgordtest.NewFakeGateway()
+	gw.Guilds = []Snowflake{100}
+	if _, err := gw.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer gw.Stop()
+
+	c, err := NewClient(context.Background(), Config{
+		BotToken:   "testing",
+		HTTPClient: &http.Client{Transport: gw.Transport()},
+		ShardConfig: ShardConfig{
+			ConnectQueue: func(shardID uint, cb func() error) error {
+				return cb()
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	guilds := make(chan *GuildCreate, 1)
+	messages := make(chan *MessageCreate, 1)
+	c.Gateway().GuildCreate(func(_ Session, evt *GuildCreate) {
+		guilds <- evt
+	})
+	c.Gateway().MessageCreate(func(_ Session, evt *MessageCreate) {
+		messages <- evt
+	})
+
+	if err = c.Gateway().Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Gateway().Disconnect()
+
+	// the shard must be identified before events can be dispatched
+	for deadline := time.Now().Add(time.Second); gw.Identifies() == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("the shard did not identify")
+		}
+		<-time.After(10 * time.Millisecond)
+	}
+
+	ensure(gw.Dispatch(0, EvtGuildCreate, &Guild{ID: 100, Name: "test", Channels: []*Channel{{ID: 101, Type: ChannelTypeGuildText}}}))
+	select {
+	case evt := <-guilds:
+		if evt.Guild.ID != 100 {
+			t.Errorf("expected guild 100, got %d", evt.Guild.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("guild create handler was not called")
+	}
+	if guild, err := c.Cache().GetGuild(100); err != nil || guild == nil || guild.Name != "test" {
+		t.Errorf("guild was not cached: %v", err)
+	}
+
+	ensure(gw.Dispatch(0, EvtMessageCreate, &Message{ID: 200, ChannelID: 101, GuildID: 100, Content: "hello", Author: &User{ID: 300}}))
+	select {
+	case evt := <-messages:
+		if evt.Message.Content != "hello" {
+			t.Errorf("expected message content hello, got %s", evt.Message.Content)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message create handler was not called")
+	}
+	// the fake gateway never responds with guild member chunks
+	defer func(timeout time.Duration) {
+		guildMembersChunkTimeout = timeout
+	}(guildMembersChunkTimeout)
+	guildMembersChunkTimeout = 50 * time.Millisecond
+
+	response, err := c.Gateway().RequestGuildMembers(&RequestGuildMembersPayload{GuildIDs: []Snowflake{100}})
+	if err == nil || response != nil {
+		t.Error("expected the guild member request to time out")
+	}
+}
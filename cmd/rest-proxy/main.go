@@ -0,0 +1,40 @@
+// rest-proxy is a reverse proxy for the Discord REST API that handles the rate limits for every process
+// using it, including services not written in Go. Requests are authorized with the bot token from the
+// incoming Authorization header, and every token gets its own rate limit buckets.
+//
+// Disgord bots use the proxy by setting Config.RESTProxyURL. Other services can replace
+// https://discord.com with the address of the proxy, as both /api/v8/.. and /v8/.. paths are accepted.
+//
+// Prometheus metrics are served at /metrics.
+//
+//	go run ./cmd/rest-proxy -addr :8080
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/andersfylling/disgord/internal/httd"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	upstream := flag.String("upstream", httd.BaseURL, "url of the Discord REST API, without the version")
+	timeout := flag.Duration("timeout", 30*time.Second, "timeout for requests to Discord")
+	maxTokens := flag.Int("max-tokens", defaultMaxClients, "number of bot tokens to keep rate limits for, the least recently used token is evicted")
+	idleTimeout := flag.Duration("token-idle-timeout", defaultClientIdleTimeout, "how long the rate limits of an unused bot token are kept")
+	flag.Parse()
+
+	p := newProxy(*upstream, &http.Client{Timeout: *timeout})
+	p.maxClients = *maxTokens
+	p.idleTimeout = *idleTimeout
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", p.serveMetrics)
+	mux.Handle("/", p)
+
+	log.Printf("forwarding requests on %s to %s", *addr, *upstream)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andersfylling/disgord/internal/constant"
+	"github.com/andersfylling/disgord/internal/httd"
+	"github.com/andersfylling/disgord/json"
+)
+
+const authorizationPrefix = "Bot "
+
+const (
+	defaultMaxClients        = 1000
+	defaultClientIdleTimeout = time.Hour
+)
+
+// tokenClient holds the buckets and metrics of a single bot token
+type tokenClient struct {
+	client *httd.Client
+
+	// id identifies the token in the metrics without exposing it
+	id string
+
+	// lastUsed is guarded by the lock of the proxy
+	lastUsed time.Time
+
+	mu          sync.Mutex
+	statusCodes map[int]uint64
+	failures    uint64
+}
+
+func (c *tokenClient) record(statusCode int, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if failed {
+		c.failures++
+	} else {
+		c.statusCodes[statusCode]++
+	}
+}
+
+type proxy struct {
+	upstream   string
+	httpClient httd.HttpClientDoer
+
+	// maxClients is the number of tokens that are kept, and idleTimeout is how long a token is kept after
+	// its last request. The buckets and metrics of a token are lost once it is evicted.
+	maxClients  int
+	idleTimeout time.Duration
+
+	mu      sync.Mutex
+	clients map[string]*tokenClient
+}
+
+func newProxy(upstream string, httpClient httd.HttpClientDoer) *proxy {
+	return &proxy{
+		upstream:    upstream,
+		httpClient:  httpClient,
+		maxClients:  defaultMaxClients,
+		idleTimeout: defaultClientIdleTimeout,
+		clients:     make(map[string]*tokenClient),
+	}
+}
+
+// client returns the client of the token for the given API version. Every token has its own buckets.
+func (p *proxy) client(token string, version int) (key string, c *tokenClient, err error) {
+	key = strconv.Itoa(version) + ":" + token
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if c, ok := p.clients[key]; ok {
+		c.lastUsed = now
+		return key, c, nil
+	}
+	p.evict(now)
+
+	client, err := httd.NewClient(&httd.Config{
+		APIVersion:         version,
+		BotToken:           token,
+		BaseURL:            p.upstream,
+		HttpClient:         p.httpClient,
+		RESTBucketManager:  httd.NewManager(nil),
+		UserAgentSourceURL: constant.GitHubURL,
+		UserAgentVersion:   constant.Version,
+		UserAgentExtra:     "rest-proxy",
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	hash := sha256.Sum256([]byte(token))
+	c = &tokenClient{
+		client:      client,
+		id:          "v" + strconv.Itoa(version) + "-" + hex.EncodeToString(hash[:6]),
+		lastUsed:    now,
+		statusCodes: make(map[int]uint64),
+	}
+	p.clients[key] = c
+	return key, c, nil
+}
+
+// evict removes the idle clients, and the least recently used client while there is no room for another
+// one. Caller must hold the lock.
+func (p *proxy) evict(now time.Time) {
+	for key, c := range p.clients {
+		if now.Sub(c.lastUsed) > p.idleTimeout {
+			delete(p.clients, key)
+		}
+	}
+
+	for len(p.clients) >= p.maxClients && len(p.clients) > 0 {
+		var oldestKey string
+		var oldest *tokenClient
+		for key, c := range p.clients {
+			if oldest == nil || c.lastUsed.Before(oldest.lastUsed) {
+				oldestKey, oldest = key, c
+			}
+		}
+		delete(p.clients, oldestKey)
+	}
+}
+
+// forget removes the client, unless it has been replaced in the meantime
+func (p *proxy) forget(key string, c *tokenClient) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.clients[key] == c {
+		delete(p.clients, key)
+	}
+}
+
+// splitVersion splits a path such as /api/v8/gateway into the API version and the endpoint
+func splitVersion(path string) (version int, endpoint string, err error) {
+	path = strings.TrimPrefix(path, "/api")
+	if !strings.HasPrefix(path, "/v") {
+		return 0, "", errors.New("missing API version in path")
+	}
+
+	path = path[len("/v"):]
+	i := strings.IndexByte(path, '/')
+	if i < 0 {
+		return 0, "", errors.New("missing endpoint in path")
+	}
+	if version, err = strconv.Atoi(path[:i]); err != nil {
+		return 0, "", errors.New("invalid API version in path")
+	}
+	if !httd.SupportsDiscordAPIVersion(version) {
+		return 0, "", fmt.Errorf("Discord API version %d is not supported", version)
+	}
+	return version, path[i:], nil
+}
+
+// newRequest creates a request for the http method, as long as Discord uses the method
+func newRequest(method string) (r *httd.Request, ok bool) {
+	r = &httd.Request{}
+	switch method {
+	case http.MethodGet:
+		r.Method = httd.MethodGet
+	case http.MethodPost:
+		r.Method = httd.MethodPost
+	case http.MethodPut:
+		r.Method = httd.MethodPut
+	case http.MethodPatch:
+		r.Method = httd.MethodPatch
+	case http.MethodDelete:
+		r.Method = httd.MethodDelete
+	default:
+		return nil, false
+	}
+	return r, true
+}
+
+// writeError responds with an error formatted like the ones from Discord
+func writeError(w http.ResponseWriter, statusCode int, msg string) {
+	w.Header().Set(httd.ContentType, httd.ContentTypeJSON)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(&struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	}{
+		Message: msg,
+	})
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, authorizationPrefix) || len(authorization) == len(authorizationPrefix) {
+		writeError(w, http.StatusUnauthorized, "401: Unauthorized")
+		return
+	}
+	token := authorization[len(authorizationPrefix):]
+
+	version, endpoint, err := splitVersion(r.URL.Path)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	req, ok := newRequest(r.Method)
+	if !ok {
+		writeError(w, http.StatusMethodNotAllowed, "405: Method Not Allowed")
+		return
+	}
+	req.Endpoint = endpoint
+	if r.URL.RawQuery != "" {
+		req.Endpoint += "?" + r.URL.RawQuery
+	}
+	req.ContentType = r.Header.Get(httd.ContentType)
+	req.Reason = r.Header.Get(httd.XAuditLogReason)
+	if r.ContentLength != 0 {
+		req.Body = r.Body
+	}
+
+	key, c, err := p.client(token, version)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp, body, err := c.client.DoRaw(r.Context(), req)
+	if err != nil {
+		c.record(0, true)
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	c.record(resp.StatusCode, false)
+	if resp.StatusCode == http.StatusUnauthorized {
+		// invalid tokens are not kept, such that random tokens do not fill up the clients
+		p.forget(key, c)
+	}
+
+	header := w.Header()
+	for name, values := range denormalizeHeader(resp.Header) {
+		header[name] = values
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(body)
+}
+
+// denormalizeHeader reverts NormalizeDiscordHeader, such that the rate limit headers look like the ones from
+// Discord. The body has already been decompressed.
+func denormalizeHeader(h http.Header) http.Header {
+	header := make(http.Header, len(h))
+	for name, values := range h {
+		header[name] = values
+	}
+	for _, name := range []string{httd.DisgordNormalizedHeader, httd.XDisgordNow, httd.ContentEncoding, "Content-Length"} {
+		header.Del(name)
+	}
+
+	if reset := header.Get(httd.XRateLimitReset); reset != "" {
+		if ms, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			seconds := float64(ms) / float64(time.Second/time.Millisecond)
+			header.Set(httd.XRateLimitReset, strconv.FormatFloat(seconds, 'f', 3, 64))
+		}
+	}
+	return header
+}
+
+// serveMetrics exposes the metrics of every token in the Prometheus text format
+func (p *proxy) serveMetrics(w http.ResponseWriter, _ *http.Request) {
+	p.mu.Lock()
+	clients := make([]*tokenClient, 0, len(p.clients))
+	for _, c := range p.clients {
+		clients = append(clients, c)
+	}
+	p.mu.Unlock()
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].id < clients[j].id
+	})
+
+	var sb strings.Builder
+	sb.WriteString("# HELP disgord_proxy_tokens Number of bot tokens seen.\n")
+	sb.WriteString("# TYPE disgord_proxy_tokens gauge\n")
+	sb.WriteString("disgord_proxy_tokens " + strconv.Itoa(len(clients)) + "\n")
+
+	sb.WriteString("# HELP disgord_proxy_responses_total Responses from Discord by token and status code.\n")
+	sb.WriteString("# TYPE disgord_proxy_responses_total counter\n")
+	for _, c := range clients {
+		c.mu.Lock()
+		codes := make([]int, 0, len(c.statusCodes))
+		for code := range c.statusCodes {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			fmt.Fprintf(&sb, "disgord_proxy_responses_total{token=%q,status=\"%d\"} %d\n", c.id, code, c.statusCodes[code])
+		}
+		c.mu.Unlock()
+	}
+
+	sb.WriteString("# HELP disgord_proxy_failures_total Requests that did not get a response from Discord, by token.\n")
+	sb.WriteString("# TYPE disgord_proxy_failures_total counter\n")
+	for _, c := range clients {
+		c.mu.Lock()
+		fmt.Fprintf(&sb, "disgord_proxy_failures_total{token=%q} %d\n", c.id, c.failures)
+		c.mu.Unlock()
+	}
+
+	sb.WriteString("# HELP disgord_proxy_buckets Number of known rate limit buckets by token.\n")
+	sb.WriteString("# TYPE disgord_proxy_buckets gauge\n")
+	for _, c := range clients {
+		fmt.Fprintf(&sb, "disgord_proxy_buckets{token=%q} %d\n", c.id, len(c.client.BucketGrouping()))
+	}
+
+	w.Header().Set(httd.ContentType, "text/plain; version=0.0.4")
+	_, _ = io.WriteString(w, sb.String())
+}
@@ -0,0 +1,236 @@
+// +build !integration
+
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord/internal/httd"
+)
+
+// upstreamRecorder is a fake Discord REST API that records the requests it receives
+type upstreamRecorder struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	respond  func(w http.ResponseWriter, r *http.Request)
+}
+
+func (u *upstreamRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u.mu.Lock()
+	u.requests = append(u.requests, r)
+	u.mu.Unlock()
+	u.respond(w, r)
+}
+
+func (u *upstreamRecorder) received() []*http.Request {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]*http.Request{}, u.requests...)
+}
+
+// newTestProxy starts a proxy in front of a fake Discord REST API. The returned function stops both servers.
+func newTestProxy(respond func(w http.ResponseWriter, r *http.Request)) (*upstreamRecorder, *httptest.Server, func()) {
+	upstream := &upstreamRecorder{respond: respond}
+	discord := httptest.NewServer(upstream)
+	server := httptest.NewServer(newProxy(discord.URL, discord.Client()))
+	return upstream, server, func() {
+		server.Close()
+		discord.Close()
+	}
+}
+
+func send(t *testing.T, method, url, token string) (*http.Response, string) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", authorizationPrefix+token)
+	}
+	req.Header.Set(httd.XAuditLogReason, "testing")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(body)
+}
+
+func TestProxy_tokens(t *testing.T) {
+	upstream, server, stop := newTestProxy(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(httd.ContentType, httd.ContentTypeJSON)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	})
+	defer stop()
+
+	if resp, _ := send(t, http.MethodGet, server.URL+"/api/v8/channels/1", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected requests without a token to be rejected, got %d", resp.StatusCode)
+	}
+	if len(upstream.received()) != 0 {
+		t.Fatal("requests without a token must not be forwarded")
+	}
+
+	for _, token := range []string{"first", "second"} {
+		resp, body := send(t, http.MethodGet, server.URL+"/api/v8/channels/1?limit=2", token)
+		if resp.StatusCode != http.StatusOK || body != `{"id":"1"}` {
+			t.Errorf("expected the response to be forwarded, got %d %s", resp.StatusCode, body)
+		}
+	}
+	if resp, _ := send(t, http.MethodGet, server.URL+"/v8/channels/1", "first"); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected paths without the /api prefix to be accepted, got %d", resp.StatusCode)
+	}
+
+	requests := upstream.received()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 forwarded requests, got %d", len(requests))
+	}
+	for i, token := range []string{"first", "second", "first"} {
+		r := requests[i]
+		if auth := r.Header.Get("Authorization"); auth != authorizationPrefix+token {
+			t.Errorf("expected the token %s to be forwarded, got %q", token, auth)
+		}
+		if r.URL.Path != "/v8/channels/1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get(httd.XAuditLogReason) != "testing" {
+			t.Error("expected the audit log reason to be forwarded")
+		}
+	}
+	if requests[0].URL.RawQuery != "limit=2" {
+		t.Errorf("expected the query to be forwarded, got %q", requests[0].URL.RawQuery)
+	}
+
+	p := server.Config.Handler.(*proxy)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.clients) != 2 {
+		t.Errorf("expected every token to have its own client, got %d", len(p.clients))
+	}
+}
+
+func TestProxy_header(t *testing.T) {
+	_, server, stop := newTestProxy(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		header.Set(httd.ContentType, httd.ContentTypeJSON)
+		header.Set(httd.XRateLimitBucket, "abcd")
+		header.Set(httd.XRateLimitLimit, "5")
+		header.Set(httd.XRateLimitRemaining, "4")
+		header.Set(httd.XRateLimitReset, "1700000000.500")
+		_, _ = w.Write([]byte(`{}`))
+	})
+	defer stop()
+
+	resp, _ := send(t, http.MethodGet, server.URL+"/api/v8/channels/1", "token")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if reset := resp.Header.Get(httd.XRateLimitReset); reset != "1700000000.500" {
+		t.Errorf("expected the reset to be in seconds like Discord, got %q", reset)
+	}
+	if resp.Header.Get(httd.XRateLimitBucket) != "abcd" || resp.Header.Get(httd.XRateLimitRemaining) != "4" {
+		t.Errorf("expected the rate limit headers to be forwarded, got %+v", resp.Header)
+	}
+	for _, name := range []string{httd.DisgordNormalizedHeader, httd.XDisgordNow} {
+		if resp.Header.Get(name) != "" {
+			t.Errorf("expected the internal header %s to be removed", name)
+		}
+	}
+}
+
+func TestProxy_tooManyRequests(t *testing.T) {
+	const body = `{"message":"You are being rate limited.","retry_after":0.5,"global":false}`
+	upstream, server, stop := newTestProxy(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		header.Set(httd.ContentType, httd.ContentTypeJSON)
+		header.Set(httd.RateLimitRetryAfter, "1")
+		header.Set(httd.XRateLimitScope, "user")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(body))
+	})
+	defer stop()
+
+	resp, respBody := send(t, http.MethodGet, server.URL+"/api/v8/channels/1", "token")
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected the 429 to be passed through, got %d", resp.StatusCode)
+	}
+	if respBody != body {
+		t.Errorf("expected the body to be passed through, got %s", respBody)
+	}
+	if resp.Header.Get(httd.RateLimitRetryAfter) != "1" || resp.Header.Get(httd.XRateLimitScope) != "user" {
+		t.Errorf("expected the rate limit headers to be passed through, got %+v", resp.Header)
+	}
+	if len(upstream.received()) != 1 {
+		t.Errorf("a 429 must not be retried by the proxy, got %d requests", len(upstream.received()))
+	}
+}
+
+func TestProxy_evict(t *testing.T) {
+	upstream, server, stop := newTestProxy(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == authorizationPrefix+"invalid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set(httd.ContentType, httd.ContentTypeJSON)
+		_, _ = w.Write([]byte(`{}`))
+	})
+	defer stop()
+
+	p := server.Config.Handler.(*proxy)
+	p.maxClients = 2
+	clients := func() map[string]*tokenClient {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		cp := make(map[string]*tokenClient)
+		for key, c := range p.clients {
+			cp[key] = c
+		}
+		return cp
+	}
+
+	if resp, _ := send(t, http.MethodGet, server.URL+"/api/v1/channels/1", "first"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected unknown API versions to be rejected, got %d", resp.StatusCode)
+	}
+	if len(upstream.received()) != 0 || len(clients()) != 0 {
+		t.Fatal("unknown API versions must not be forwarded or get a client")
+	}
+
+	if resp, _ := send(t, http.MethodGet, server.URL+"/api/v8/channels/1", "invalid"); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected the 401 to be passed through, got %d", resp.StatusCode)
+	}
+	if len(clients()) != 0 {
+		t.Error("expected the client of an invalid token to be removed")
+	}
+
+	for _, token := range []string{"first", "second", "first", "third"} {
+		send(t, http.MethodGet, server.URL+"/api/v8/channels/1", token)
+	}
+	current := clients()
+	if len(current) != 2 {
+		t.Fatalf("expected the number of clients to be capped at 2, got %d", len(current))
+	}
+	if _, ok := current["8:second"]; ok {
+		t.Error("expected the least recently used client to be evicted")
+	}
+
+	p.mu.Lock()
+	p.clients["8:first"].lastUsed = time.Now().Add(-2 * p.idleTimeout)
+	p.mu.Unlock()
+	send(t, http.MethodGet, server.URL+"/api/v8/channels/1", "second")
+	current = clients()
+	if _, ok := current["8:first"]; ok {
+		t.Error("expected the idle client to be evicted")
+	}
+	if len(current) != 2 {
+		t.Errorf("expected 2 clients, got %d", len(current))
+	}
+}
@@ -0,0 +1,558 @@
+// Package disgordtest helps testing bots without connecting to Discord.
+//
+// A FakeGateway imitates the Discord event gateway, such that the handlers and the cache of a Disgord
+// client can be tested with scripted events:
+//  gw := disgordtest.NewFakeGateway()
+//  if _, err := gw.Start(); err != nil {
+//      panic(err)
+//  }
+//  defer gw.Stop()
+//
+//  client := disgord.New(disgord.Config{
+//      BotToken:   "testing",
+//      HTTPClient: &http.Client{Transport: gw.Transport()},
+//  })
+//  // register handlers, connect and wait for gw.Identifies() to be larger than 0
+//  err := gw.Dispatch(0, disgord.EvtMessageCreate, &disgord.Message{ID: 1, Content: "hello"})
+package disgordtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andersfylling/disgord/internal/gateway/opcode"
+	"github.com/andersfylling/disgord/internal/util"
+	"github.com/andersfylling/disgord/json"
+
+	"nhooyr.io/websocket"
+)
+
+// FakeGateway is an in-process imitation of the Discord event gateway, for testing only. It supports
+// hello, identify, heartbeats, resume, invalid session, reconnect requests and close codes, while the
+// events are scripted by the test using Dispatch.
+//
+// Shards can connect using a FakeConn, see Conn, or over a websocket connection to a local server, see Start.
+type FakeGateway struct {
+	sync.Mutex
+
+	// HeartbeatInterval in milliseconds sent in the hello packet. Defaults to 41250.
+	HeartbeatInterval uint
+
+	// BotID is the user id given in the Ready event
+	BotID util.Snowflake
+
+	// Guilds are given as unavailable guilds in the Ready event
+	Guilds []util.Snowflake
+
+	// DisableHeartbeatAcks stops the gateway from replying to heartbeats
+	DisableHeartbeatAcks bool
+
+	// RejectResumes replies to resume packets with a non-resumable invalid session
+	RejectResumes bool
+
+	sessions    map[*fakeSession]bool
+	sequences   map[string]uint32 // session id => last sequence number
+	sessionIDs  uint64
+	identifies  int
+	resumes     int
+	heartbeats  int
+	received    []json.RawMessage
+	server      *http.Server
+	listener    net.Listener
+	connections sync.WaitGroup
+}
+
+// NewFakeGateway creates a fake gateway without any connections.
+func NewFakeGateway() *FakeGateway {
+	return &FakeGateway{
+		sessions:  make(map[*fakeSession]bool),
+		sequences: make(map[string]uint32),
+	}
+}
+
+// Conn creates a new in-memory connection to the gateway, without a local server. Every Open establishes a new
+// websocket session.
+func (g *FakeGateway) Conn() *FakeConn {
+	return &FakeConn{gateway: g}
+}
+
+// Start serves the gateway over websocket on a local port. The URL is returned.
+//
+// Requests for /gateway and /gateway/bot are answered as well, such that a http.Client using Transport
+// can be given to a Disgord client.
+func (g *FakeGateway) Start() (url string, err error) {
+	g.Lock()
+	defer g.Unlock()
+	if g.server != nil {
+		return "", errors.New("the fake gateway is already started")
+	}
+
+	if g.listener, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		return "", err
+	}
+	g.server = &http.Server{Handler: http.HandlerFunc(g.serveHTTP)}
+	go func(server *http.Server, listener net.Listener) {
+		_ = server.Serve(listener)
+	}(g.server, g.listener)
+
+	return g.url(), nil
+}
+
+// Stop closes every connection and the local server, if started.
+func (g *FakeGateway) Stop() error {
+	g.Close(int(websocket.StatusGoingAway), "fake gateway is shutting down")
+
+	g.Lock()
+	server := g.server
+	g.server = nil
+	g.Unlock()
+	if server == nil {
+		return nil
+	}
+
+	err := server.Close()
+	g.connections.Wait()
+	return err
+}
+
+func (g *FakeGateway) url() string {
+	return "ws://" + g.listener.Addr().String()
+}
+
+// Transport routes every request to the local server. Use it to let a Disgord client fetch the gateway
+// information without connecting to Discord.
+func (g *FakeGateway) Transport() http.RoundTripper {
+	return fakeTransport{gateway: g}
+}
+
+type fakeTransport struct {
+	gateway *FakeGateway
+}
+
+func (t fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	t.gateway.serveHTTP(recorder, req)
+	resp := recorder.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+func (g *FakeGateway) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/gateway/bot"), strings.HasSuffix(r.URL.Path, "/gateway"):
+		g.Lock()
+		if g.server == nil {
+			g.Unlock()
+			http.Error(w, "the fake gateway must be started to serve gateway information", http.StatusServiceUnavailable)
+			return
+		}
+		body := fmt.Sprintf(`{"url":%q,"shards":1,"session_start_limit":{"total":1000,"remaining":1000,"reset_after":0,"max_concurrency":1}}`, g.url())
+		g.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	case r.Header.Get("Upgrade") == "websocket":
+		g.serveWebsocket(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (g *FakeGateway) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	g.connections.Add(1)
+	defer g.connections.Done()
+
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	conn.SetReadLimit(32768 * 10000)
+	session := g.newSession()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for {
+			select {
+			case frame := <-session.frames:
+				if frame.closeErr != nil {
+					_ = conn.Close(websocket.StatusCode(frame.closeErr.Code), frame.closeErr.Reason)
+					cancel()
+					return
+				}
+				if err := conn.Write(ctx, websocket.MessageText, frame.data); err != nil {
+					cancel()
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			break
+		}
+		session.handle(data)
+	}
+	g.removeSession(session)
+	_ = conn.Close(websocket.StatusNormalClosure, "")
+}
+
+// Dispatch sends an event to every session identified as the given shard. The data is marshalled to json.
+func (g *FakeGateway) Dispatch(shardID uint, name string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	g.Lock()
+	defer g.Unlock()
+
+	var dispatched bool
+	for session := range g.sessions {
+		if !session.identified || session.shardID != shardID {
+			continue
+		}
+		session.dispatch(name, raw)
+		dispatched = true
+	}
+	if !dispatched {
+		return fmt.Errorf("no session has identified as shard %d", shardID)
+	}
+	return nil
+}
+
+// InvalidateSessions sends an invalid session packet to every session.
+func (g *FakeGateway) InvalidateSessions(resumable bool) {
+	g.Lock()
+	defer g.Unlock()
+	for session := range g.sessions {
+		session.send(opcode.EventInvalidSession, resumable)
+		if !resumable {
+			delete(g.sequences, session.sessionID)
+		}
+	}
+}
+
+// RequestReconnect asks every session to reconnect and resume (op 7).
+func (g *FakeGateway) RequestReconnect() {
+	g.Lock()
+	defer g.Unlock()
+	for session := range g.sessions {
+		session.send(opcode.EventReconnect, nil)
+	}
+}
+
+// Close closes every session with the given websocket close code, eg. 4000 or 4011.
+func (g *FakeGateway) Close(code int, reason string) {
+	g.Lock()
+	defer g.Unlock()
+	for session := range g.sessions {
+		session.closeWith(&CloseError{Code: code, Reason: reason})
+		delete(g.sessions, session)
+	}
+}
+
+// Identifies returns the number of received identify packets.
+func (g *FakeGateway) Identifies() int {
+	g.Lock()
+	defer g.Unlock()
+	return g.identifies
+}
+
+// Resumes returns the number of received resume packets, including rejected ones.
+func (g *FakeGateway) Resumes() int {
+	g.Lock()
+	defer g.Unlock()
+	return g.resumes
+}
+
+// Heartbeats returns the number of received heartbeat packets.
+func (g *FakeGateway) Heartbeats() int {
+	g.Lock()
+	defer g.Unlock()
+	return g.heartbeats
+}
+
+// Sessions returns the number of open sessions.
+func (g *FakeGateway) Sessions() int {
+	g.Lock()
+	defer g.Unlock()
+	return len(g.sessions)
+}
+
+// Received returns every packet sent to the gateway, in order. Tokens are not removed.
+func (g *FakeGateway) Received() []json.RawMessage {
+	g.Lock()
+	defer g.Unlock()
+
+	received := make([]json.RawMessage, len(g.received))
+	copy(received, g.received)
+	return received
+}
+
+func (g *FakeGateway) newSession() *fakeSession {
+	session := &fakeSession{
+		gateway: g,
+		frames:  make(chan fakeFrame, 1000),
+		closed:  make(chan struct{}),
+	}
+
+	g.Lock()
+	defer g.Unlock()
+	g.sessions[session] = true
+
+	interval := g.HeartbeatInterval
+	if interval == 0 {
+		interval = 41250
+	}
+	session.send(opcode.EventHello, &struct {
+		HeartbeatInterval uint `json:"heartbeat_interval"`
+	}{HeartbeatInterval: interval})
+	return session
+}
+
+func (g *FakeGateway) removeSession(session *fakeSession) {
+	g.Lock()
+	defer g.Unlock()
+	session.closeWith(nil)
+	delete(g.sessions, session)
+}
+
+// CloseError is returned by FakeConn.Read when the gateway closed the session with a websocket close code.
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("websocket closed with code %d: %s", e.Code, e.Reason)
+}
+
+// packet is the json structure of the packets sent by Discord
+type packet struct {
+	Op             opcode.OpCode   `json:"op"`
+	Data           json.RawMessage `json:"d"`
+	SequenceNumber uint32          `json:"s,omitempty"`
+	EventName      string          `json:"t,omitempty"`
+}
+
+type fakeFrame struct {
+	data     []byte
+	closeErr *CloseError
+}
+
+// fakeSession is a single websocket connection to the fake gateway. It must only be
+// used while holding the gateway lock.
+type fakeSession struct {
+	gateway    *FakeGateway
+	frames     chan fakeFrame
+	closed     chan struct{}
+	closeOnce  sync.Once
+	identified bool
+	shardID    uint
+	sessionID  string
+	sequence   uint32
+}
+
+func (s *fakeSession) send(op opcode.OpCode, data interface{}) {
+	raw, _ := json.Marshal(data)
+	s.write(&packet{Op: op, Data: raw})
+}
+
+func (s *fakeSession) dispatch(name string, data []byte) {
+	s.sequence++
+	s.gateway.sequences[s.sessionID] = s.sequence
+	s.write(&packet{Op: opcode.EventDiscordEvent, EventName: name, SequenceNumber: s.sequence, Data: data})
+}
+
+func (s *fakeSession) write(p *packet) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return
+	}
+
+	select {
+	case <-s.closed:
+	case s.frames <- fakeFrame{data: data}:
+	}
+}
+
+func (s *fakeSession) closeWith(closeErr *CloseError) {
+	s.closeOnce.Do(func() {
+		if closeErr != nil {
+			select {
+			case s.frames <- fakeFrame{closeErr: closeErr}:
+			default: // the shard is not reading, so the close code is lost
+			}
+		}
+		close(s.closed)
+	})
+}
+
+// handle reacts to a packet sent by a shard.
+func (s *fakeSession) handle(data []byte) {
+	received := &packet{}
+	if err := json.Unmarshal(data, received); err != nil {
+		return
+	}
+
+	g := s.gateway
+	g.Lock()
+	defer g.Unlock()
+	if !g.sessions[s] {
+		return
+	}
+	g.received = append(g.received, data)
+
+	switch received.Op {
+	case opcode.EventHeartbeat:
+		g.heartbeats++
+		if !g.DisableHeartbeatAcks {
+			s.send(opcode.EventHeartbeatAck, nil)
+		}
+	case opcode.EventIdentify:
+		g.identifies++
+		identity := &struct {
+			Shard *[2]uint `json:"shard,omitempty"`
+		}{}
+		if err := json.Unmarshal(received.Data, identity); err != nil {
+			s.closeWith(&CloseError{Code: 4002, Reason: "Error while decoding payload."})
+			return
+		}
+		shard := [2]uint{0, 1}
+		if identity.Shard != nil {
+			shard = *identity.Shard
+		}
+
+		g.sessionIDs++
+		s.identified = true
+		s.shardID = shard[0]
+		s.sessionID = "fake" + strconv.FormatUint(g.sessionIDs, 10)
+		s.sequence = 0
+		s.dispatch("READY", s.readyPayload(shard))
+	case opcode.EventResume:
+		g.resumes++
+		resume := &struct {
+			SessionID string `json:"session_id"`
+		}{}
+		if err := json.Unmarshal(received.Data, resume); err != nil {
+			s.closeWith(&CloseError{Code: 4002, Reason: "Error while decoding payload."})
+			return
+		}
+		sequence, known := g.sequences[resume.SessionID]
+		if g.RejectResumes || !known {
+			s.send(opcode.EventInvalidSession, false)
+			return
+		}
+
+		s.identified = true
+		s.sessionID = resume.SessionID
+		s.sequence = sequence
+		s.dispatch("RESUMED", []byte(`{}`))
+	}
+}
+
+func (s *fakeSession) readyPayload(shard [2]uint) []byte {
+	guilds := make([]string, len(s.gateway.Guilds))
+	for i := range s.gateway.Guilds {
+		guilds[i] = fmt.Sprintf(`{"id":"%d","unavailable":true}`, s.gateway.Guilds[i])
+	}
+
+	format := `{"v":8,"user":{"id":"%d","username":"fake","discriminator":"0000","bot":true},"guilds":[%s],"session_id":%q,"shard":[%d,%d]}`
+	return []byte(fmt.Sprintf(format, s.gateway.BotID, strings.Join(guilds, ","), s.sessionID, shard[0], shard[1]))
+}
+
+// FakeConn is an in-memory connection to a FakeGateway, see FakeGateway.Conn.
+type FakeConn struct {
+	sync.Mutex
+	gateway *FakeGateway
+	session *fakeSession
+}
+
+func (c *FakeConn) Open(_ context.Context, _ string, _ http.Header) error {
+	c.Lock()
+	defer c.Unlock()
+	if c.session != nil {
+		return errors.New("fake connection is already open")
+	}
+	c.session = c.gateway.newSession()
+	return nil
+}
+
+func (c *FakeConn) Close() error {
+	c.Lock()
+	session := c.session
+	c.session = nil
+	c.Unlock()
+
+	if session != nil {
+		c.gateway.removeSession(session)
+	}
+	return nil
+}
+
+func (c *FakeConn) WriteJSON(v interface{}) error {
+	c.Lock()
+	session := c.session
+	c.Unlock()
+	if session == nil {
+		return errors.New("fake connection is closed")
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	session.handle(data)
+	return nil
+}
+
+func (c *FakeConn) Read(ctx context.Context) (packet []byte, err error) {
+	c.Lock()
+	session := c.session
+	c.Unlock()
+	if session == nil {
+		return nil, errors.New("fake connection is closed")
+	}
+
+	var frame fakeFrame
+	select {
+	case frame = <-session.frames:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-session.closed:
+		// pending frames, such as a close code, must still be read
+		select {
+		case frame = <-session.frames:
+		default:
+			return nil, &CloseError{Code: int(websocket.StatusAbnormalClosure), Reason: "fake connection was closed"}
+		}
+	}
+
+	if frame.closeErr != nil {
+		c.Lock()
+		if c.session == session {
+			c.session = nil
+		}
+		c.Unlock()
+		return nil, frame.closeErr
+	}
+	return frame.data, nil
+}
+
+func (c *FakeConn) Disconnected() bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.session == nil
+}
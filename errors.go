@@ -2,9 +2,15 @@ package disgord
 
 import (
 	"github.com/andersfylling/disgord/internal/disgorderr"
+	"github.com/andersfylling/disgord/internal/httd"
 )
 
+//go:generate go run internal/generate/errorcodes/main.go
+
 // TODO: go generate from internal/errors/*
 type Err = disgorderr.Err
 type CloseConnectionErr = disgorderr.ClosedConnectionErr
 type HandlerSpecErr = disgorderr.HandlerSpecErr
+
+// RESTFieldError is a validation error of a single field in a REST request body. See ErrRest.FieldErrors.
+type RESTFieldError = httd.FieldError
@@ -22,6 +22,7 @@ type EventType interface {
 
 type evtResource interface {
 	setShardID(id uint)
+	getShardID() uint
 }
 
 // ---------------------------
@@ -201,6 +202,7 @@ type MessageDelete struct {
 type MessageDeleteBulk struct {
 	MessageIDs []Snowflake `json:"ids"`
 	ChannelID  Snowflake   `json:"channel_id"`
+	GuildID    Snowflake   `json:"guild_id"`
 	ShardID    uint        `json:"-"`
 }
 
@@ -212,6 +214,7 @@ type MessageDeleteBulk struct {
 type MessageReactionAdd struct {
 	UserID    Snowflake `json:"user_id"`
 	ChannelID Snowflake `json:"channel_id"`
+	GuildID   Snowflake `json:"guild_id"`
 	MessageID Snowflake `json:"message_id"`
 	// PartialEmoji id and name. id might be nil
 	PartialEmoji *Emoji `json:"emoji"`
@@ -243,6 +246,7 @@ type InteractionCreate struct {
 type MessageReactionRemove struct {
 	UserID    Snowflake `json:"user_id"`
 	ChannelID Snowflake `json:"channel_id"`
+	GuildID   Snowflake `json:"guild_id"`
 	MessageID Snowflake `json:"message_id"`
 	// PartialEmoji id and name. id might be nil
 	PartialEmoji *Emoji `json:"emoji"`
@@ -254,6 +258,7 @@ type MessageReactionRemove struct {
 // MessageReactionRemoveAll all reactions were explicitly removed from a message
 type MessageReactionRemoveAll struct {
 	ChannelID Snowflake `json:"channel_id"`
+	GuildID   Snowflake `json:"guild_id"`
 	MessageID Snowflake `json:"message_id"`
 	ShardID   uint      `json:"-"`
 }
@@ -423,7 +428,7 @@ type GuildMembersChunk struct {
 	Members    []*Member         `json:"members"`
 	ChunkIndex uint              `json:"chunk_index"`
 	ChunkCount uint              `json:"chunk_count"`
-	NotFound   []interface{}     `json:"not_found"`
+	NotFound   []Snowflake       `json:"not_found"`
 	Presences  []*PresenceUpdate `json:"presences"`
 	Nonce      string            `json:"nonce"`
 	ShardID    uint              `json:"-"`
@@ -564,3 +569,60 @@ type InviteCreate struct {
 
 	ShardID uint `json:"-"`
 }
+
+// ---------------------------
+
+// ShardConnected a shard has identified and is ready to receive events
+type ShardConnected struct {
+	ShardID uint `json:"-"`
+}
+
+// ---------------------------
+
+// ShardDisconnected a shard lost its connection to Discord
+type ShardDisconnected struct {
+	ShardID uint `json:"-"`
+}
+
+// ---------------------------
+
+// ShardResumed a shard resumed its previous session
+type ShardResumed struct {
+	ShardID uint `json:"-"`
+}
+
+// ---------------------------
+
+// ShardScaled the shards were re-created with a new total shard count. ShardID is the
+// first local shard, see ShardIDs for all the shards that exists on this instance.
+type ShardScaled struct {
+	ShardCount uint   `json:"shard_count"`
+	ShardIDs   []uint `json:"shard_ids"`
+	ShardID    uint   `json:"-"`
+}
+
+// ---------------------------
+
+// VoiceSpeaking a user started speaking in a voice channel that the bot is connected to. The SSRC identifies
+// the audio of the user, see VoicePacket.
+type VoiceSpeaking struct {
+	GuildID Snowflake `json:"guild_id"`
+	UserID  Snowflake `json:"user_id"`
+	SSRC    uint32    `json:"ssrc"`
+
+	// Speaking is a bitmask, where 1 is voice audio, 2 is audio from a stream and 4 is priority speaking.
+	Speaking uint8 `json:"speaking"`
+
+	ShardID uint `json:"-"`
+}
+
+// ---------------------------
+
+// VoiceClientDisconnect a user left a voice channel that the bot is connected to. SSRC is zero when the
+// user never spoke while the bot was connected.
+type VoiceClientDisconnect struct {
+	GuildID Snowflake `json:"guild_id"`
+	UserID  Snowflake `json:"user_id"`
+	SSRC    uint32    `json:"ssrc"`
+	ShardID uint      `json:"-"`
+}
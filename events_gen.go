@@ -25,6 +25,7 @@ func AllEventsExcept(except ...string) []string {
 const EvtChannelCreate = event.ChannelCreate
 
 func (h *ChannelCreate) setShardID(id uint) { h.ShardID = id }
+func (h *ChannelCreate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -33,6 +34,7 @@ func (h *ChannelCreate) setShardID(id uint) { h.ShardID = id }
 const EvtChannelDelete = event.ChannelDelete
 
 func (h *ChannelDelete) setShardID(id uint) { h.ShardID = id }
+func (h *ChannelDelete) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -41,6 +43,7 @@ func (h *ChannelDelete) setShardID(id uint) { h.ShardID = id }
 const EvtChannelPinsUpdate = event.ChannelPinsUpdate
 
 func (h *ChannelPinsUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *ChannelPinsUpdate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -49,6 +52,7 @@ func (h *ChannelPinsUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtChannelUpdate = event.ChannelUpdate
 
 func (h *ChannelUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *ChannelUpdate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -57,6 +61,7 @@ func (h *ChannelUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtGuildBanAdd = event.GuildBanAdd
 
 func (h *GuildBanAdd) setShardID(id uint) { h.ShardID = id }
+func (h *GuildBanAdd) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -65,6 +70,7 @@ func (h *GuildBanAdd) setShardID(id uint) { h.ShardID = id }
 const EvtGuildBanRemove = event.GuildBanRemove
 
 func (h *GuildBanRemove) setShardID(id uint) { h.ShardID = id }
+func (h *GuildBanRemove) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -77,6 +83,7 @@ func (h *GuildBanRemove) setShardID(id uint) { h.ShardID = id }
 const EvtGuildCreate = event.GuildCreate
 
 func (h *GuildCreate) setShardID(id uint) { h.ShardID = id }
+func (h *GuildCreate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -87,6 +94,7 @@ func (h *GuildCreate) setShardID(id uint) { h.ShardID = id }
 const EvtGuildDelete = event.GuildDelete
 
 func (h *GuildDelete) setShardID(id uint) { h.ShardID = id }
+func (h *GuildDelete) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -95,6 +103,7 @@ func (h *GuildDelete) setShardID(id uint) { h.ShardID = id }
 const EvtGuildEmojisUpdate = event.GuildEmojisUpdate
 
 func (h *GuildEmojisUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *GuildEmojisUpdate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -103,6 +112,7 @@ func (h *GuildEmojisUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtGuildIntegrationsUpdate = event.GuildIntegrationsUpdate
 
 func (h *GuildIntegrationsUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *GuildIntegrationsUpdate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -111,6 +121,7 @@ func (h *GuildIntegrationsUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtGuildMemberAdd = event.GuildMemberAdd
 
 func (h *GuildMemberAdd) setShardID(id uint) { h.ShardID = id }
+func (h *GuildMemberAdd) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -119,6 +130,7 @@ func (h *GuildMemberAdd) setShardID(id uint) { h.ShardID = id }
 const EvtGuildMemberRemove = event.GuildMemberRemove
 
 func (h *GuildMemberRemove) setShardID(id uint) { h.ShardID = id }
+func (h *GuildMemberRemove) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -127,6 +139,7 @@ func (h *GuildMemberRemove) setShardID(id uint) { h.ShardID = id }
 const EvtGuildMemberUpdate = event.GuildMemberUpdate
 
 func (h *GuildMemberUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *GuildMemberUpdate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -135,6 +148,7 @@ func (h *GuildMemberUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtGuildMembersChunk = event.GuildMembersChunk
 
 func (h *GuildMembersChunk) setShardID(id uint) { h.ShardID = id }
+func (h *GuildMembersChunk) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -143,6 +157,7 @@ func (h *GuildMembersChunk) setShardID(id uint) { h.ShardID = id }
 const EvtGuildRoleCreate = event.GuildRoleCreate
 
 func (h *GuildRoleCreate) setShardID(id uint) { h.ShardID = id }
+func (h *GuildRoleCreate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -151,6 +166,7 @@ func (h *GuildRoleCreate) setShardID(id uint) { h.ShardID = id }
 const EvtGuildRoleDelete = event.GuildRoleDelete
 
 func (h *GuildRoleDelete) setShardID(id uint) { h.ShardID = id }
+func (h *GuildRoleDelete) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -159,6 +175,7 @@ func (h *GuildRoleDelete) setShardID(id uint) { h.ShardID = id }
 const EvtGuildRoleUpdate = event.GuildRoleUpdate
 
 func (h *GuildRoleUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *GuildRoleUpdate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -167,6 +184,7 @@ func (h *GuildRoleUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtGuildUpdate = event.GuildUpdate
 
 func (h *GuildUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *GuildUpdate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -175,6 +193,7 @@ func (h *GuildUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtInteractionCreate = event.InteractionCreate
 
 func (h *InteractionCreate) setShardID(id uint) { h.ShardID = id }
+func (h *InteractionCreate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -183,6 +202,7 @@ func (h *InteractionCreate) setShardID(id uint) { h.ShardID = id }
 const EvtInviteCreate = event.InviteCreate
 
 func (h *InviteCreate) setShardID(id uint) { h.ShardID = id }
+func (h *InviteCreate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -191,6 +211,7 @@ func (h *InviteCreate) setShardID(id uint) { h.ShardID = id }
 const EvtInviteDelete = event.InviteDelete
 
 func (h *InviteDelete) setShardID(id uint) { h.ShardID = id }
+func (h *InviteDelete) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -199,6 +220,7 @@ func (h *InviteDelete) setShardID(id uint) { h.ShardID = id }
 const EvtMessageCreate = event.MessageCreate
 
 func (h *MessageCreate) setShardID(id uint) { h.ShardID = id }
+func (h *MessageCreate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -207,6 +229,7 @@ func (h *MessageCreate) setShardID(id uint) { h.ShardID = id }
 const EvtMessageDelete = event.MessageDelete
 
 func (h *MessageDelete) setShardID(id uint) { h.ShardID = id }
+func (h *MessageDelete) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -215,6 +238,7 @@ func (h *MessageDelete) setShardID(id uint) { h.ShardID = id }
 const EvtMessageDeleteBulk = event.MessageDeleteBulk
 
 func (h *MessageDeleteBulk) setShardID(id uint) { h.ShardID = id }
+func (h *MessageDeleteBulk) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -223,6 +247,7 @@ func (h *MessageDeleteBulk) setShardID(id uint) { h.ShardID = id }
 const EvtMessageReactionAdd = event.MessageReactionAdd
 
 func (h *MessageReactionAdd) setShardID(id uint) { h.ShardID = id }
+func (h *MessageReactionAdd) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -231,6 +256,7 @@ func (h *MessageReactionAdd) setShardID(id uint) { h.ShardID = id }
 const EvtMessageReactionRemove = event.MessageReactionRemove
 
 func (h *MessageReactionRemove) setShardID(id uint) { h.ShardID = id }
+func (h *MessageReactionRemove) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -239,6 +265,7 @@ func (h *MessageReactionRemove) setShardID(id uint) { h.ShardID = id }
 const EvtMessageReactionRemoveAll = event.MessageReactionRemoveAll
 
 func (h *MessageReactionRemoveAll) setShardID(id uint) { h.ShardID = id }
+func (h *MessageReactionRemoveAll) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -247,6 +274,7 @@ func (h *MessageReactionRemoveAll) setShardID(id uint) { h.ShardID = id }
 const EvtMessageReactionRemoveEmoji = event.MessageReactionRemoveEmoji
 
 func (h *MessageReactionRemoveEmoji) setShardID(id uint) { h.ShardID = id }
+func (h *MessageReactionRemoveEmoji) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -257,6 +285,7 @@ func (h *MessageReactionRemoveEmoji) setShardID(id uint) { h.ShardID = id }
 const EvtMessageUpdate = event.MessageUpdate
 
 func (h *MessageUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *MessageUpdate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -265,6 +294,7 @@ func (h *MessageUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtPresenceUpdate = event.PresenceUpdate
 
 func (h *PresenceUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *PresenceUpdate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -275,6 +305,7 @@ func (h *PresenceUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtReady = event.Ready
 
 func (h *Ready) setShardID(id uint) { h.ShardID = id }
+func (h *Ready) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -284,6 +315,45 @@ func (h *Ready) setShardID(id uint) { h.ShardID = id }
 const EvtResumed = event.Resumed
 
 func (h *Resumed) setShardID(id uint) { h.ShardID = id }
+func (h *Resumed) getShardID() uint   { return h.ShardID }
+
+// ---------------------------
+
+// EvtShardConnected Sent when a shard has identified and received a Ready event from Discord.
+//
+const EvtShardConnected = event.ShardConnected
+
+func (h *ShardConnected) setShardID(id uint) { h.ShardID = id }
+func (h *ShardConnected) getShardID() uint   { return h.ShardID }
+
+// ---------------------------
+
+// EvtShardDisconnected Sent when a shard has lost its connection to Discord. The shard will try to reconnect
+// unless the disconnect was requested or Discord rejected the connection.
+//
+const EvtShardDisconnected = event.ShardDisconnected
+
+func (h *ShardDisconnected) setShardID(id uint) { h.ShardID = id }
+func (h *ShardDisconnected) getShardID() uint   { return h.ShardID }
+
+// ---------------------------
+
+// EvtShardResumed Sent when a shard has resumed a previous session and Discord replied with a Resumed event.
+//
+const EvtShardResumed = event.ShardResumed
+
+func (h *ShardResumed) setShardID(id uint) { h.ShardID = id }
+func (h *ShardResumed) getShardID() uint   { return h.ShardID }
+
+// ---------------------------
+
+// EvtShardScaled Sent when the shards have been scaled, either due to Discord requiring more shards
+// or a manual re-shard. The payload holds the new total shard count and the local shard IDs.
+//
+const EvtShardScaled = event.ShardScaled
+
+func (h *ShardScaled) setShardID(id uint) { h.ShardID = id }
+func (h *ShardScaled) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -292,6 +362,7 @@ func (h *Resumed) setShardID(id uint) { h.ShardID = id }
 const EvtTypingStart = event.TypingStart
 
 func (h *TypingStart) setShardID(id uint) { h.ShardID = id }
+func (h *TypingStart) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -300,6 +371,16 @@ func (h *TypingStart) setShardID(id uint) { h.ShardID = id }
 const EvtUserUpdate = event.UserUpdate
 
 func (h *UserUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *UserUpdate) getShardID() uint   { return h.ShardID }
+
+// ---------------------------
+
+// EvtVoiceClientDisconnect Sent when a user leaves a voice channel that the bot is connected to. Only sent for
+// voice connections created by Disgord.
+const EvtVoiceClientDisconnect = event.VoiceClientDisconnect
+
+func (h *VoiceClientDisconnect) setShardID(id uint) { h.ShardID = id }
+func (h *VoiceClientDisconnect) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -309,6 +390,16 @@ func (h *UserUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtVoiceServerUpdate = event.VoiceServerUpdate
 
 func (h *VoiceServerUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *VoiceServerUpdate) getShardID() uint   { return h.ShardID }
+
+// ---------------------------
+
+// EvtVoiceSpeaking Sent when a user in a voice channel that the bot is connected to starts speaking, which
+// links the SSRC of their audio to the user. Only sent for voice connections created by Disgord.
+const EvtVoiceSpeaking = event.VoiceSpeaking
+
+func (h *VoiceSpeaking) setShardID(id uint) { h.ShardID = id }
+func (h *VoiceSpeaking) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -317,6 +408,7 @@ func (h *VoiceServerUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtVoiceStateUpdate = event.VoiceStateUpdate
 
 func (h *VoiceStateUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *VoiceStateUpdate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -325,6 +417,7 @@ func (h *VoiceStateUpdate) setShardID(id uint) { h.ShardID = id }
 const EvtWebhooksUpdate = event.WebhooksUpdate
 
 func (h *WebhooksUpdate) setShardID(id uint) { h.ShardID = id }
+func (h *WebhooksUpdate) getShardID() uint   { return h.ShardID }
 
 // ---------------------------
 
@@ -393,6 +486,16 @@ func (shr socketHandlerRegister) ChannelCreateChan(handler chan *ChannelCreate,
 	shr.build()
 }
 
+// ChannelCreateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) ChannelCreateErr(handler HandlerChannelCreateErr, moreHandlers ...HandlerChannelCreateErr) {
+	shr.evtName = EvtChannelCreate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // ChannelDelete Sent when a channel relevant to the current user is deleted. The inner payload is a DM or Guild channel object.
 //
 func (shr socketHandlerRegister) ChannelDelete(handler HandlerChannelDelete, moreHandlers ...HandlerChannelDelete) {
@@ -413,6 +516,16 @@ func (shr socketHandlerRegister) ChannelDeleteChan(handler chan *ChannelDelete,
 	shr.build()
 }
 
+// ChannelDeleteErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) ChannelDeleteErr(handler HandlerChannelDeleteErr, moreHandlers ...HandlerChannelDeleteErr) {
+	shr.evtName = EvtChannelDelete
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // ChannelPinsUpdate Sent when a message is pinned or unpinned in a text channel. This is not sent when a pinned message is deleted.
 //
 func (shr socketHandlerRegister) ChannelPinsUpdate(handler HandlerChannelPinsUpdate, moreHandlers ...HandlerChannelPinsUpdate) {
@@ -433,6 +546,16 @@ func (shr socketHandlerRegister) ChannelPinsUpdateChan(handler chan *ChannelPins
 	shr.build()
 }
 
+// ChannelPinsUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) ChannelPinsUpdateErr(handler HandlerChannelPinsUpdateErr, moreHandlers ...HandlerChannelPinsUpdateErr) {
+	shr.evtName = EvtChannelPinsUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // ChannelUpdate Sent when a channel is updated. The inner payload is a guild channel object.
 //
 func (shr socketHandlerRegister) ChannelUpdate(handler HandlerChannelUpdate, moreHandlers ...HandlerChannelUpdate) {
@@ -453,6 +576,16 @@ func (shr socketHandlerRegister) ChannelUpdateChan(handler chan *ChannelUpdate,
 	shr.build()
 }
 
+// ChannelUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) ChannelUpdateErr(handler HandlerChannelUpdateErr, moreHandlers ...HandlerChannelUpdateErr) {
+	shr.evtName = EvtChannelUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildBanAdd Sent when a user is banned from a guild. The inner payload is a user object, with an extra guild_id key.
 //
 func (shr socketHandlerRegister) GuildBanAdd(handler HandlerGuildBanAdd, moreHandlers ...HandlerGuildBanAdd) {
@@ -473,6 +606,16 @@ func (shr socketHandlerRegister) GuildBanAddChan(handler chan *GuildBanAdd, more
 	shr.build()
 }
 
+// GuildBanAddErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildBanAddErr(handler HandlerGuildBanAddErr, moreHandlers ...HandlerGuildBanAddErr) {
+	shr.evtName = EvtGuildBanAdd
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildBanRemove Sent when a user is unbanned from a guild. The inner payload is a user object, with an extra guild_id key.
 //
 func (shr socketHandlerRegister) GuildBanRemove(handler HandlerGuildBanRemove, moreHandlers ...HandlerGuildBanRemove) {
@@ -493,6 +636,16 @@ func (shr socketHandlerRegister) GuildBanRemoveChan(handler chan *GuildBanRemove
 	shr.build()
 }
 
+// GuildBanRemoveErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildBanRemoveErr(handler HandlerGuildBanRemoveErr, moreHandlers ...HandlerGuildBanRemoveErr) {
+	shr.evtName = EvtGuildBanRemove
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildCreate This event can be sent in three different scenarios:
 //  1. When a user is initially connecting, to lazily load and backfill information for all unavailable guilds
 //     sent in the Ready event.
@@ -517,6 +670,16 @@ func (shr socketHandlerRegister) GuildCreateChan(handler chan *GuildCreate, more
 	shr.build()
 }
 
+// GuildCreateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildCreateErr(handler HandlerGuildCreateErr, moreHandlers ...HandlerGuildCreateErr) {
+	shr.evtName = EvtGuildCreate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildDelete Sent when a guild becomes unavailable during a guild outage, or when the user leaves or is removed from a guild.
 // The inner payload is an unavailable guild object. If the unavailable field is not set, the user was removed
 // from the guild.
@@ -539,6 +702,16 @@ func (shr socketHandlerRegister) GuildDeleteChan(handler chan *GuildDelete, more
 	shr.build()
 }
 
+// GuildDeleteErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildDeleteErr(handler HandlerGuildDeleteErr, moreHandlers ...HandlerGuildDeleteErr) {
+	shr.evtName = EvtGuildDelete
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildEmojisUpdate Sent when a guild's emojis have been updated.
 //
 func (shr socketHandlerRegister) GuildEmojisUpdate(handler HandlerGuildEmojisUpdate, moreHandlers ...HandlerGuildEmojisUpdate) {
@@ -559,6 +732,16 @@ func (shr socketHandlerRegister) GuildEmojisUpdateChan(handler chan *GuildEmojis
 	shr.build()
 }
 
+// GuildEmojisUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildEmojisUpdateErr(handler HandlerGuildEmojisUpdateErr, moreHandlers ...HandlerGuildEmojisUpdateErr) {
+	shr.evtName = EvtGuildEmojisUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildIntegrationsUpdate Sent when a guild integration is updated.
 //
 func (shr socketHandlerRegister) GuildIntegrationsUpdate(handler HandlerGuildIntegrationsUpdate, moreHandlers ...HandlerGuildIntegrationsUpdate) {
@@ -579,6 +762,16 @@ func (shr socketHandlerRegister) GuildIntegrationsUpdateChan(handler chan *Guild
 	shr.build()
 }
 
+// GuildIntegrationsUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildIntegrationsUpdateErr(handler HandlerGuildIntegrationsUpdateErr, moreHandlers ...HandlerGuildIntegrationsUpdateErr) {
+	shr.evtName = EvtGuildIntegrationsUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildMemberAdd Sent when a new user joins a guild.
 //
 func (shr socketHandlerRegister) GuildMemberAdd(handler HandlerGuildMemberAdd, moreHandlers ...HandlerGuildMemberAdd) {
@@ -599,6 +792,16 @@ func (shr socketHandlerRegister) GuildMemberAddChan(handler chan *GuildMemberAdd
 	shr.build()
 }
 
+// GuildMemberAddErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildMemberAddErr(handler HandlerGuildMemberAddErr, moreHandlers ...HandlerGuildMemberAddErr) {
+	shr.evtName = EvtGuildMemberAdd
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildMemberRemove Sent when a user is removed from a guild (leave/kick/ban).
 //
 func (shr socketHandlerRegister) GuildMemberRemove(handler HandlerGuildMemberRemove, moreHandlers ...HandlerGuildMemberRemove) {
@@ -619,6 +822,16 @@ func (shr socketHandlerRegister) GuildMemberRemoveChan(handler chan *GuildMember
 	shr.build()
 }
 
+// GuildMemberRemoveErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildMemberRemoveErr(handler HandlerGuildMemberRemoveErr, moreHandlers ...HandlerGuildMemberRemoveErr) {
+	shr.evtName = EvtGuildMemberRemove
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildMemberUpdate Sent when a guild member is updated.
 //
 func (shr socketHandlerRegister) GuildMemberUpdate(handler HandlerGuildMemberUpdate, moreHandlers ...HandlerGuildMemberUpdate) {
@@ -639,6 +852,16 @@ func (shr socketHandlerRegister) GuildMemberUpdateChan(handler chan *GuildMember
 	shr.build()
 }
 
+// GuildMemberUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildMemberUpdateErr(handler HandlerGuildMemberUpdateErr, moreHandlers ...HandlerGuildMemberUpdateErr) {
+	shr.evtName = EvtGuildMemberUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildMembersChunk Sent in response to Gateway Request Guild Members.
 //
 func (shr socketHandlerRegister) GuildMembersChunk(handler HandlerGuildMembersChunk, moreHandlers ...HandlerGuildMembersChunk) {
@@ -659,6 +882,16 @@ func (shr socketHandlerRegister) GuildMembersChunkChan(handler chan *GuildMember
 	shr.build()
 }
 
+// GuildMembersChunkErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildMembersChunkErr(handler HandlerGuildMembersChunkErr, moreHandlers ...HandlerGuildMembersChunkErr) {
+	shr.evtName = EvtGuildMembersChunk
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildRoleCreate Sent when a guild role is created.
 //
 func (shr socketHandlerRegister) GuildRoleCreate(handler HandlerGuildRoleCreate, moreHandlers ...HandlerGuildRoleCreate) {
@@ -679,6 +912,16 @@ func (shr socketHandlerRegister) GuildRoleCreateChan(handler chan *GuildRoleCrea
 	shr.build()
 }
 
+// GuildRoleCreateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildRoleCreateErr(handler HandlerGuildRoleCreateErr, moreHandlers ...HandlerGuildRoleCreateErr) {
+	shr.evtName = EvtGuildRoleCreate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildRoleDelete Sent when a guild role is created.
 //
 func (shr socketHandlerRegister) GuildRoleDelete(handler HandlerGuildRoleDelete, moreHandlers ...HandlerGuildRoleDelete) {
@@ -699,6 +942,16 @@ func (shr socketHandlerRegister) GuildRoleDeleteChan(handler chan *GuildRoleDele
 	shr.build()
 }
 
+// GuildRoleDeleteErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildRoleDeleteErr(handler HandlerGuildRoleDeleteErr, moreHandlers ...HandlerGuildRoleDeleteErr) {
+	shr.evtName = EvtGuildRoleDelete
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildRoleUpdate Sent when a guild role is created.
 //
 func (shr socketHandlerRegister) GuildRoleUpdate(handler HandlerGuildRoleUpdate, moreHandlers ...HandlerGuildRoleUpdate) {
@@ -719,6 +972,16 @@ func (shr socketHandlerRegister) GuildRoleUpdateChan(handler chan *GuildRoleUpda
 	shr.build()
 }
 
+// GuildRoleUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildRoleUpdateErr(handler HandlerGuildRoleUpdateErr, moreHandlers ...HandlerGuildRoleUpdateErr) {
+	shr.evtName = EvtGuildRoleUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildUpdate Sent when a guild is updated. The inner payload is a guild object.
 //
 func (shr socketHandlerRegister) GuildUpdate(handler HandlerGuildUpdate, moreHandlers ...HandlerGuildUpdate) {
@@ -739,6 +1002,16 @@ func (shr socketHandlerRegister) GuildUpdateChan(handler chan *GuildUpdate, more
 	shr.build()
 }
 
+// GuildUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) GuildUpdateErr(handler HandlerGuildUpdateErr, moreHandlers ...HandlerGuildUpdateErr) {
+	shr.evtName = EvtGuildUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // InteractionCreate Sent when a user in a guild uses a Slash Command.
 //
 func (shr socketHandlerRegister) InteractionCreate(handler HandlerInteractionCreate, moreHandlers ...HandlerInteractionCreate) {
@@ -759,6 +1032,16 @@ func (shr socketHandlerRegister) InteractionCreateChan(handler chan *Interaction
 	shr.build()
 }
 
+// InteractionCreateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) InteractionCreateErr(handler HandlerInteractionCreateErr, moreHandlers ...HandlerInteractionCreateErr) {
+	shr.evtName = EvtInteractionCreate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // InviteCreate Sent when a guild's invite is created.
 //
 func (shr socketHandlerRegister) InviteCreate(handler HandlerInviteCreate, moreHandlers ...HandlerInviteCreate) {
@@ -779,6 +1062,16 @@ func (shr socketHandlerRegister) InviteCreateChan(handler chan *InviteCreate, mo
 	shr.build()
 }
 
+// InviteCreateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) InviteCreateErr(handler HandlerInviteCreateErr, moreHandlers ...HandlerInviteCreateErr) {
+	shr.evtName = EvtInviteCreate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // InviteDelete Sent when an invite is deleted.
 //
 func (shr socketHandlerRegister) InviteDelete(handler HandlerInviteDelete, moreHandlers ...HandlerInviteDelete) {
@@ -799,6 +1092,16 @@ func (shr socketHandlerRegister) InviteDeleteChan(handler chan *InviteDelete, mo
 	shr.build()
 }
 
+// InviteDeleteErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) InviteDeleteErr(handler HandlerInviteDeleteErr, moreHandlers ...HandlerInviteDeleteErr) {
+	shr.evtName = EvtInviteDelete
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // MessageCreate Sent when a message is created. The inner payload is a message object.
 //
 func (shr socketHandlerRegister) MessageCreate(handler HandlerMessageCreate, moreHandlers ...HandlerMessageCreate) {
@@ -819,6 +1122,16 @@ func (shr socketHandlerRegister) MessageCreateChan(handler chan *MessageCreate,
 	shr.build()
 }
 
+// MessageCreateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) MessageCreateErr(handler HandlerMessageCreateErr, moreHandlers ...HandlerMessageCreateErr) {
+	shr.evtName = EvtMessageCreate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // MessageDelete Sent when a message is deleted.
 //
 func (shr socketHandlerRegister) MessageDelete(handler HandlerMessageDelete, moreHandlers ...HandlerMessageDelete) {
@@ -839,6 +1152,16 @@ func (shr socketHandlerRegister) MessageDeleteChan(handler chan *MessageDelete,
 	shr.build()
 }
 
+// MessageDeleteErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) MessageDeleteErr(handler HandlerMessageDeleteErr, moreHandlers ...HandlerMessageDeleteErr) {
+	shr.evtName = EvtMessageDelete
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // MessageDeleteBulk Sent when multiple messages are deleted at once.
 //
 func (shr socketHandlerRegister) MessageDeleteBulk(handler HandlerMessageDeleteBulk, moreHandlers ...HandlerMessageDeleteBulk) {
@@ -859,6 +1182,16 @@ func (shr socketHandlerRegister) MessageDeleteBulkChan(handler chan *MessageDele
 	shr.build()
 }
 
+// MessageDeleteBulkErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) MessageDeleteBulkErr(handler HandlerMessageDeleteBulkErr, moreHandlers ...HandlerMessageDeleteBulkErr) {
+	shr.evtName = EvtMessageDeleteBulk
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // MessageReactionAdd Sent when a user adds a reaction to a message.
 //
 func (shr socketHandlerRegister) MessageReactionAdd(handler HandlerMessageReactionAdd, moreHandlers ...HandlerMessageReactionAdd) {
@@ -879,6 +1212,16 @@ func (shr socketHandlerRegister) MessageReactionAddChan(handler chan *MessageRea
 	shr.build()
 }
 
+// MessageReactionAddErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) MessageReactionAddErr(handler HandlerMessageReactionAddErr, moreHandlers ...HandlerMessageReactionAddErr) {
+	shr.evtName = EvtMessageReactionAdd
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // MessageReactionRemove Sent when a user removes a reaction from a message.
 //
 func (shr socketHandlerRegister) MessageReactionRemove(handler HandlerMessageReactionRemove, moreHandlers ...HandlerMessageReactionRemove) {
@@ -899,6 +1242,16 @@ func (shr socketHandlerRegister) MessageReactionRemoveChan(handler chan *Message
 	shr.build()
 }
 
+// MessageReactionRemoveErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) MessageReactionRemoveErr(handler HandlerMessageReactionRemoveErr, moreHandlers ...HandlerMessageReactionRemoveErr) {
+	shr.evtName = EvtMessageReactionRemove
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // MessageReactionRemoveAll Sent when a user explicitly removes all reactions from a message.
 //
 func (shr socketHandlerRegister) MessageReactionRemoveAll(handler HandlerMessageReactionRemoveAll, moreHandlers ...HandlerMessageReactionRemoveAll) {
@@ -919,6 +1272,16 @@ func (shr socketHandlerRegister) MessageReactionRemoveAllChan(handler chan *Mess
 	shr.build()
 }
 
+// MessageReactionRemoveAllErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) MessageReactionRemoveAllErr(handler HandlerMessageReactionRemoveAllErr, moreHandlers ...HandlerMessageReactionRemoveAllErr) {
+	shr.evtName = EvtMessageReactionRemoveAll
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // MessageReactionRemoveEmoji Sent when a bot removes all instances of a given emoji from the reactions of a message.
 //
 func (shr socketHandlerRegister) MessageReactionRemoveEmoji(handler HandlerMessageReactionRemoveEmoji, moreHandlers ...HandlerMessageReactionRemoveEmoji) {
@@ -939,6 +1302,16 @@ func (shr socketHandlerRegister) MessageReactionRemoveEmojiChan(handler chan *Me
 	shr.build()
 }
 
+// MessageReactionRemoveEmojiErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) MessageReactionRemoveEmojiErr(handler HandlerMessageReactionRemoveEmojiErr, moreHandlers ...HandlerMessageReactionRemoveEmojiErr) {
+	shr.evtName = EvtMessageReactionRemoveEmoji
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // MessageUpdate Sent when a message is updated. The inner payload is a message object.
 //
 // NOTE! Has _at_least_ the GuildID and ChannelID fields.
@@ -961,6 +1334,16 @@ func (shr socketHandlerRegister) MessageUpdateChan(handler chan *MessageUpdate,
 	shr.build()
 }
 
+// MessageUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) MessageUpdateErr(handler HandlerMessageUpdateErr, moreHandlers ...HandlerMessageUpdateErr) {
+	shr.evtName = EvtMessageUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // PresenceUpdate A user's presence is their current state on a guild. This event is sent when a user's presence is updated for a guild.
 //
 func (shr socketHandlerRegister) PresenceUpdate(handler HandlerPresenceUpdate, moreHandlers ...HandlerPresenceUpdate) {
@@ -981,6 +1364,16 @@ func (shr socketHandlerRegister) PresenceUpdateChan(handler chan *PresenceUpdate
 	shr.build()
 }
 
+// PresenceUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) PresenceUpdateErr(handler HandlerPresenceUpdateErr, moreHandlers ...HandlerPresenceUpdateErr) {
+	shr.evtName = EvtPresenceUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // Ready The ready event is dispatched when a client has completed the initial handshake with the gateway (for new sessions).
 // The ready event can be the largest and most complex event the gateway will send, as it contains all the state
 // required for a client to begin interacting with the rest of the platform.
@@ -1003,6 +1396,16 @@ func (shr socketHandlerRegister) ReadyChan(handler chan *Ready, moreHandlers ...
 	shr.build()
 }
 
+// ReadyErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) ReadyErr(handler HandlerReadyErr, moreHandlers ...HandlerReadyErr) {
+	shr.evtName = EvtReady
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // Resumed The resumed event is dispatched when a client has sent a resume payload to the gateway
 // (for resuming existing sessions).
 //
@@ -1024,6 +1427,138 @@ func (shr socketHandlerRegister) ResumedChan(handler chan *Resumed, moreHandlers
 	shr.build()
 }
 
+// ResumedErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) ResumedErr(handler HandlerResumedErr, moreHandlers ...HandlerResumedErr) {
+	shr.evtName = EvtResumed
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// ShardConnected Sent when a shard has identified and received a Ready event from Discord.
+//
+func (shr socketHandlerRegister) ShardConnected(handler HandlerShardConnected, moreHandlers ...HandlerShardConnected) {
+	shr.evtName = EvtShardConnected
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+func (shr socketHandlerRegister) ShardConnectedChan(handler chan *ShardConnected, moreHandlers ...chan *ShardConnected) {
+	shr.evtName = EvtShardConnected
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// ShardConnectedErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) ShardConnectedErr(handler HandlerShardConnectedErr, moreHandlers ...HandlerShardConnectedErr) {
+	shr.evtName = EvtShardConnected
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// ShardDisconnected Sent when a shard has lost its connection to Discord. The shard will try to reconnect
+// unless the disconnect was requested or Discord rejected the connection.
+//
+func (shr socketHandlerRegister) ShardDisconnected(handler HandlerShardDisconnected, moreHandlers ...HandlerShardDisconnected) {
+	shr.evtName = EvtShardDisconnected
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+func (shr socketHandlerRegister) ShardDisconnectedChan(handler chan *ShardDisconnected, moreHandlers ...chan *ShardDisconnected) {
+	shr.evtName = EvtShardDisconnected
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// ShardDisconnectedErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) ShardDisconnectedErr(handler HandlerShardDisconnectedErr, moreHandlers ...HandlerShardDisconnectedErr) {
+	shr.evtName = EvtShardDisconnected
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// ShardResumed Sent when a shard has resumed a previous session and Discord replied with a Resumed event.
+//
+func (shr socketHandlerRegister) ShardResumed(handler HandlerShardResumed, moreHandlers ...HandlerShardResumed) {
+	shr.evtName = EvtShardResumed
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+func (shr socketHandlerRegister) ShardResumedChan(handler chan *ShardResumed, moreHandlers ...chan *ShardResumed) {
+	shr.evtName = EvtShardResumed
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// ShardResumedErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) ShardResumedErr(handler HandlerShardResumedErr, moreHandlers ...HandlerShardResumedErr) {
+	shr.evtName = EvtShardResumed
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// ShardScaled Sent when the shards have been scaled, either due to Discord requiring more shards
+// or a manual re-shard. The payload holds the new total shard count and the local shard IDs.
+//
+func (shr socketHandlerRegister) ShardScaled(handler HandlerShardScaled, moreHandlers ...HandlerShardScaled) {
+	shr.evtName = EvtShardScaled
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+func (shr socketHandlerRegister) ShardScaledChan(handler chan *ShardScaled, moreHandlers ...chan *ShardScaled) {
+	shr.evtName = EvtShardScaled
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// ShardScaledErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) ShardScaledErr(handler HandlerShardScaledErr, moreHandlers ...HandlerShardScaledErr) {
+	shr.evtName = EvtShardScaled
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // TypingStart Sent when a user starts typing in a channel.
 //
 func (shr socketHandlerRegister) TypingStart(handler HandlerTypingStart, moreHandlers ...HandlerTypingStart) {
@@ -1044,6 +1579,16 @@ func (shr socketHandlerRegister) TypingStartChan(handler chan *TypingStart, more
 	shr.build()
 }
 
+// TypingStartErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) TypingStartErr(handler HandlerTypingStartErr, moreHandlers ...HandlerTypingStartErr) {
+	shr.evtName = EvtTypingStart
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // UserUpdate Sent when properties about the user change. Inner payload is a user object.
 //
 func (shr socketHandlerRegister) UserUpdate(handler HandlerUserUpdate, moreHandlers ...HandlerUserUpdate) {
@@ -1064,6 +1609,46 @@ func (shr socketHandlerRegister) UserUpdateChan(handler chan *UserUpdate, moreHa
 	shr.build()
 }
 
+// UserUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) UserUpdateErr(handler HandlerUserUpdateErr, moreHandlers ...HandlerUserUpdateErr) {
+	shr.evtName = EvtUserUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// VoiceClientDisconnect Sent when a user leaves a voice channel that the bot is connected to. Only sent for
+// voice connections created by Disgord.
+func (shr socketHandlerRegister) VoiceClientDisconnect(handler HandlerVoiceClientDisconnect, moreHandlers ...HandlerVoiceClientDisconnect) {
+	shr.evtName = EvtVoiceClientDisconnect
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+func (shr socketHandlerRegister) VoiceClientDisconnectChan(handler chan *VoiceClientDisconnect, moreHandlers ...chan *VoiceClientDisconnect) {
+	shr.evtName = EvtVoiceClientDisconnect
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// VoiceClientDisconnectErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) VoiceClientDisconnectErr(handler HandlerVoiceClientDisconnectErr, moreHandlers ...HandlerVoiceClientDisconnectErr) {
+	shr.evtName = EvtVoiceClientDisconnect
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // VoiceServerUpdate Sent when a guild's voice server is updated. This is sent when initially connecting to voice, and when the current
 // voice instance fails over to a new server.
 //
@@ -1085,6 +1670,46 @@ func (shr socketHandlerRegister) VoiceServerUpdateChan(handler chan *VoiceServer
 	shr.build()
 }
 
+// VoiceServerUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) VoiceServerUpdateErr(handler HandlerVoiceServerUpdateErr, moreHandlers ...HandlerVoiceServerUpdateErr) {
+	shr.evtName = EvtVoiceServerUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// VoiceSpeaking Sent when a user in a voice channel that the bot is connected to starts speaking, which
+// links the SSRC of their audio to the user. Only sent for voice connections created by Disgord.
+func (shr socketHandlerRegister) VoiceSpeaking(handler HandlerVoiceSpeaking, moreHandlers ...HandlerVoiceSpeaking) {
+	shr.evtName = EvtVoiceSpeaking
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+func (shr socketHandlerRegister) VoiceSpeakingChan(handler chan *VoiceSpeaking, moreHandlers ...chan *VoiceSpeaking) {
+	shr.evtName = EvtVoiceSpeaking
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+// VoiceSpeakingErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) VoiceSpeakingErr(handler HandlerVoiceSpeakingErr, moreHandlers ...HandlerVoiceSpeakingErr) {
+	shr.evtName = EvtVoiceSpeaking
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // VoiceStateUpdate Sent when someone joins/leaves/moves voice channels. Inner payload is a voice state object.
 //
 func (shr socketHandlerRegister) VoiceStateUpdate(handler HandlerVoiceStateUpdate, moreHandlers ...HandlerVoiceStateUpdate) {
@@ -1105,6 +1730,16 @@ func (shr socketHandlerRegister) VoiceStateUpdateChan(handler chan *VoiceStateUp
 	shr.build()
 }
 
+// VoiceStateUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) VoiceStateUpdateErr(handler HandlerVoiceStateUpdateErr, moreHandlers ...HandlerVoiceStateUpdateErr) {
+	shr.evtName = EvtVoiceStateUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // WebhooksUpdate Sent when a guild channel's WebHook is created, updated, or deleted.
 //
 func (shr socketHandlerRegister) WebhooksUpdate(handler HandlerWebhooksUpdate, moreHandlers ...HandlerWebhooksUpdate) {
@@ -1125,81 +1760,146 @@ func (shr socketHandlerRegister) WebhooksUpdateChan(handler chan *WebhooksUpdate
 	shr.build()
 }
 
+// WebhooksUpdateErr registers handlers that can fail, see Config.OnHandlerError.
+func (shr socketHandlerRegister) WebhooksUpdateErr(handler HandlerWebhooksUpdateErr, moreHandlers ...HandlerWebhooksUpdateErr) {
+	shr.evtName = EvtWebhooksUpdate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 type SocketHandlerRegistrator interface {
 	ChannelCreate(handler HandlerChannelCreate, moreHandlers ...HandlerChannelCreate)
 	ChannelCreateChan(handler chan *ChannelCreate, moreHandlers ...chan *ChannelCreate)
+	ChannelCreateErr(handler HandlerChannelCreateErr, moreHandlers ...HandlerChannelCreateErr)
 	ChannelDelete(handler HandlerChannelDelete, moreHandlers ...HandlerChannelDelete)
 	ChannelDeleteChan(handler chan *ChannelDelete, moreHandlers ...chan *ChannelDelete)
+	ChannelDeleteErr(handler HandlerChannelDeleteErr, moreHandlers ...HandlerChannelDeleteErr)
 	ChannelPinsUpdate(handler HandlerChannelPinsUpdate, moreHandlers ...HandlerChannelPinsUpdate)
 	ChannelPinsUpdateChan(handler chan *ChannelPinsUpdate, moreHandlers ...chan *ChannelPinsUpdate)
+	ChannelPinsUpdateErr(handler HandlerChannelPinsUpdateErr, moreHandlers ...HandlerChannelPinsUpdateErr)
 	ChannelUpdate(handler HandlerChannelUpdate, moreHandlers ...HandlerChannelUpdate)
 	ChannelUpdateChan(handler chan *ChannelUpdate, moreHandlers ...chan *ChannelUpdate)
+	ChannelUpdateErr(handler HandlerChannelUpdateErr, moreHandlers ...HandlerChannelUpdateErr)
 	GuildBanAdd(handler HandlerGuildBanAdd, moreHandlers ...HandlerGuildBanAdd)
 	GuildBanAddChan(handler chan *GuildBanAdd, moreHandlers ...chan *GuildBanAdd)
+	GuildBanAddErr(handler HandlerGuildBanAddErr, moreHandlers ...HandlerGuildBanAddErr)
 	GuildBanRemove(handler HandlerGuildBanRemove, moreHandlers ...HandlerGuildBanRemove)
 	GuildBanRemoveChan(handler chan *GuildBanRemove, moreHandlers ...chan *GuildBanRemove)
+	GuildBanRemoveErr(handler HandlerGuildBanRemoveErr, moreHandlers ...HandlerGuildBanRemoveErr)
 	GuildCreate(handler HandlerGuildCreate, moreHandlers ...HandlerGuildCreate)
 	GuildCreateChan(handler chan *GuildCreate, moreHandlers ...chan *GuildCreate)
+	GuildCreateErr(handler HandlerGuildCreateErr, moreHandlers ...HandlerGuildCreateErr)
 	GuildDelete(handler HandlerGuildDelete, moreHandlers ...HandlerGuildDelete)
 	GuildDeleteChan(handler chan *GuildDelete, moreHandlers ...chan *GuildDelete)
+	GuildDeleteErr(handler HandlerGuildDeleteErr, moreHandlers ...HandlerGuildDeleteErr)
 	GuildEmojisUpdate(handler HandlerGuildEmojisUpdate, moreHandlers ...HandlerGuildEmojisUpdate)
 	GuildEmojisUpdateChan(handler chan *GuildEmojisUpdate, moreHandlers ...chan *GuildEmojisUpdate)
+	GuildEmojisUpdateErr(handler HandlerGuildEmojisUpdateErr, moreHandlers ...HandlerGuildEmojisUpdateErr)
 	GuildIntegrationsUpdate(handler HandlerGuildIntegrationsUpdate, moreHandlers ...HandlerGuildIntegrationsUpdate)
 	GuildIntegrationsUpdateChan(handler chan *GuildIntegrationsUpdate, moreHandlers ...chan *GuildIntegrationsUpdate)
+	GuildIntegrationsUpdateErr(handler HandlerGuildIntegrationsUpdateErr, moreHandlers ...HandlerGuildIntegrationsUpdateErr)
 	GuildMemberAdd(handler HandlerGuildMemberAdd, moreHandlers ...HandlerGuildMemberAdd)
 	GuildMemberAddChan(handler chan *GuildMemberAdd, moreHandlers ...chan *GuildMemberAdd)
+	GuildMemberAddErr(handler HandlerGuildMemberAddErr, moreHandlers ...HandlerGuildMemberAddErr)
 	GuildMemberRemove(handler HandlerGuildMemberRemove, moreHandlers ...HandlerGuildMemberRemove)
 	GuildMemberRemoveChan(handler chan *GuildMemberRemove, moreHandlers ...chan *GuildMemberRemove)
+	GuildMemberRemoveErr(handler HandlerGuildMemberRemoveErr, moreHandlers ...HandlerGuildMemberRemoveErr)
 	GuildMemberUpdate(handler HandlerGuildMemberUpdate, moreHandlers ...HandlerGuildMemberUpdate)
 	GuildMemberUpdateChan(handler chan *GuildMemberUpdate, moreHandlers ...chan *GuildMemberUpdate)
+	GuildMemberUpdateErr(handler HandlerGuildMemberUpdateErr, moreHandlers ...HandlerGuildMemberUpdateErr)
 	GuildMembersChunk(handler HandlerGuildMembersChunk, moreHandlers ...HandlerGuildMembersChunk)
 	GuildMembersChunkChan(handler chan *GuildMembersChunk, moreHandlers ...chan *GuildMembersChunk)
+	GuildMembersChunkErr(handler HandlerGuildMembersChunkErr, moreHandlers ...HandlerGuildMembersChunkErr)
 	GuildRoleCreate(handler HandlerGuildRoleCreate, moreHandlers ...HandlerGuildRoleCreate)
 	GuildRoleCreateChan(handler chan *GuildRoleCreate, moreHandlers ...chan *GuildRoleCreate)
+	GuildRoleCreateErr(handler HandlerGuildRoleCreateErr, moreHandlers ...HandlerGuildRoleCreateErr)
 	GuildRoleDelete(handler HandlerGuildRoleDelete, moreHandlers ...HandlerGuildRoleDelete)
 	GuildRoleDeleteChan(handler chan *GuildRoleDelete, moreHandlers ...chan *GuildRoleDelete)
+	GuildRoleDeleteErr(handler HandlerGuildRoleDeleteErr, moreHandlers ...HandlerGuildRoleDeleteErr)
 	GuildRoleUpdate(handler HandlerGuildRoleUpdate, moreHandlers ...HandlerGuildRoleUpdate)
 	GuildRoleUpdateChan(handler chan *GuildRoleUpdate, moreHandlers ...chan *GuildRoleUpdate)
+	GuildRoleUpdateErr(handler HandlerGuildRoleUpdateErr, moreHandlers ...HandlerGuildRoleUpdateErr)
 	GuildUpdate(handler HandlerGuildUpdate, moreHandlers ...HandlerGuildUpdate)
 	GuildUpdateChan(handler chan *GuildUpdate, moreHandlers ...chan *GuildUpdate)
+	GuildUpdateErr(handler HandlerGuildUpdateErr, moreHandlers ...HandlerGuildUpdateErr)
 	InteractionCreate(handler HandlerInteractionCreate, moreHandlers ...HandlerInteractionCreate)
 	InteractionCreateChan(handler chan *InteractionCreate, moreHandlers ...chan *InteractionCreate)
+	InteractionCreateErr(handler HandlerInteractionCreateErr, moreHandlers ...HandlerInteractionCreateErr)
 	InviteCreate(handler HandlerInviteCreate, moreHandlers ...HandlerInviteCreate)
 	InviteCreateChan(handler chan *InviteCreate, moreHandlers ...chan *InviteCreate)
+	InviteCreateErr(handler HandlerInviteCreateErr, moreHandlers ...HandlerInviteCreateErr)
 	InviteDelete(handler HandlerInviteDelete, moreHandlers ...HandlerInviteDelete)
 	InviteDeleteChan(handler chan *InviteDelete, moreHandlers ...chan *InviteDelete)
+	InviteDeleteErr(handler HandlerInviteDeleteErr, moreHandlers ...HandlerInviteDeleteErr)
 	MessageCreate(handler HandlerMessageCreate, moreHandlers ...HandlerMessageCreate)
 	MessageCreateChan(handler chan *MessageCreate, moreHandlers ...chan *MessageCreate)
+	MessageCreateErr(handler HandlerMessageCreateErr, moreHandlers ...HandlerMessageCreateErr)
 	MessageDelete(handler HandlerMessageDelete, moreHandlers ...HandlerMessageDelete)
 	MessageDeleteChan(handler chan *MessageDelete, moreHandlers ...chan *MessageDelete)
+	MessageDeleteErr(handler HandlerMessageDeleteErr, moreHandlers ...HandlerMessageDeleteErr)
 	MessageDeleteBulk(handler HandlerMessageDeleteBulk, moreHandlers ...HandlerMessageDeleteBulk)
 	MessageDeleteBulkChan(handler chan *MessageDeleteBulk, moreHandlers ...chan *MessageDeleteBulk)
+	MessageDeleteBulkErr(handler HandlerMessageDeleteBulkErr, moreHandlers ...HandlerMessageDeleteBulkErr)
 	MessageReactionAdd(handler HandlerMessageReactionAdd, moreHandlers ...HandlerMessageReactionAdd)
 	MessageReactionAddChan(handler chan *MessageReactionAdd, moreHandlers ...chan *MessageReactionAdd)
+	MessageReactionAddErr(handler HandlerMessageReactionAddErr, moreHandlers ...HandlerMessageReactionAddErr)
 	MessageReactionRemove(handler HandlerMessageReactionRemove, moreHandlers ...HandlerMessageReactionRemove)
 	MessageReactionRemoveChan(handler chan *MessageReactionRemove, moreHandlers ...chan *MessageReactionRemove)
+	MessageReactionRemoveErr(handler HandlerMessageReactionRemoveErr, moreHandlers ...HandlerMessageReactionRemoveErr)
 	MessageReactionRemoveAll(handler HandlerMessageReactionRemoveAll, moreHandlers ...HandlerMessageReactionRemoveAll)
 	MessageReactionRemoveAllChan(handler chan *MessageReactionRemoveAll, moreHandlers ...chan *MessageReactionRemoveAll)
+	MessageReactionRemoveAllErr(handler HandlerMessageReactionRemoveAllErr, moreHandlers ...HandlerMessageReactionRemoveAllErr)
 	MessageReactionRemoveEmoji(handler HandlerMessageReactionRemoveEmoji, moreHandlers ...HandlerMessageReactionRemoveEmoji)
 	MessageReactionRemoveEmojiChan(handler chan *MessageReactionRemoveEmoji, moreHandlers ...chan *MessageReactionRemoveEmoji)
+	MessageReactionRemoveEmojiErr(handler HandlerMessageReactionRemoveEmojiErr, moreHandlers ...HandlerMessageReactionRemoveEmojiErr)
 	MessageUpdate(handler HandlerMessageUpdate, moreHandlers ...HandlerMessageUpdate)
 	MessageUpdateChan(handler chan *MessageUpdate, moreHandlers ...chan *MessageUpdate)
+	MessageUpdateErr(handler HandlerMessageUpdateErr, moreHandlers ...HandlerMessageUpdateErr)
 	PresenceUpdate(handler HandlerPresenceUpdate, moreHandlers ...HandlerPresenceUpdate)
 	PresenceUpdateChan(handler chan *PresenceUpdate, moreHandlers ...chan *PresenceUpdate)
+	PresenceUpdateErr(handler HandlerPresenceUpdateErr, moreHandlers ...HandlerPresenceUpdateErr)
 	Ready(handler HandlerReady, moreHandlers ...HandlerReady)
 	ReadyChan(handler chan *Ready, moreHandlers ...chan *Ready)
+	ReadyErr(handler HandlerReadyErr, moreHandlers ...HandlerReadyErr)
 	Resumed(handler HandlerResumed, moreHandlers ...HandlerResumed)
 	ResumedChan(handler chan *Resumed, moreHandlers ...chan *Resumed)
+	ResumedErr(handler HandlerResumedErr, moreHandlers ...HandlerResumedErr)
+	ShardConnected(handler HandlerShardConnected, moreHandlers ...HandlerShardConnected)
+	ShardConnectedChan(handler chan *ShardConnected, moreHandlers ...chan *ShardConnected)
+	ShardConnectedErr(handler HandlerShardConnectedErr, moreHandlers ...HandlerShardConnectedErr)
+	ShardDisconnected(handler HandlerShardDisconnected, moreHandlers ...HandlerShardDisconnected)
+	ShardDisconnectedChan(handler chan *ShardDisconnected, moreHandlers ...chan *ShardDisconnected)
+	ShardDisconnectedErr(handler HandlerShardDisconnectedErr, moreHandlers ...HandlerShardDisconnectedErr)
+	ShardResumed(handler HandlerShardResumed, moreHandlers ...HandlerShardResumed)
+	ShardResumedChan(handler chan *ShardResumed, moreHandlers ...chan *ShardResumed)
+	ShardResumedErr(handler HandlerShardResumedErr, moreHandlers ...HandlerShardResumedErr)
+	ShardScaled(handler HandlerShardScaled, moreHandlers ...HandlerShardScaled)
+	ShardScaledChan(handler chan *ShardScaled, moreHandlers ...chan *ShardScaled)
+	ShardScaledErr(handler HandlerShardScaledErr, moreHandlers ...HandlerShardScaledErr)
 	TypingStart(handler HandlerTypingStart, moreHandlers ...HandlerTypingStart)
 	TypingStartChan(handler chan *TypingStart, moreHandlers ...chan *TypingStart)
+	TypingStartErr(handler HandlerTypingStartErr, moreHandlers ...HandlerTypingStartErr)
 	UserUpdate(handler HandlerUserUpdate, moreHandlers ...HandlerUserUpdate)
 	UserUpdateChan(handler chan *UserUpdate, moreHandlers ...chan *UserUpdate)
+	UserUpdateErr(handler HandlerUserUpdateErr, moreHandlers ...HandlerUserUpdateErr)
+	VoiceClientDisconnect(handler HandlerVoiceClientDisconnect, moreHandlers ...HandlerVoiceClientDisconnect)
+	VoiceClientDisconnectChan(handler chan *VoiceClientDisconnect, moreHandlers ...chan *VoiceClientDisconnect)
+	VoiceClientDisconnectErr(handler HandlerVoiceClientDisconnectErr, moreHandlers ...HandlerVoiceClientDisconnectErr)
 	VoiceServerUpdate(handler HandlerVoiceServerUpdate, moreHandlers ...HandlerVoiceServerUpdate)
 	VoiceServerUpdateChan(handler chan *VoiceServerUpdate, moreHandlers ...chan *VoiceServerUpdate)
+	VoiceServerUpdateErr(handler HandlerVoiceServerUpdateErr, moreHandlers ...HandlerVoiceServerUpdateErr)
+	VoiceSpeaking(handler HandlerVoiceSpeaking, moreHandlers ...HandlerVoiceSpeaking)
+	VoiceSpeakingChan(handler chan *VoiceSpeaking, moreHandlers ...chan *VoiceSpeaking)
+	VoiceSpeakingErr(handler HandlerVoiceSpeakingErr, moreHandlers ...HandlerVoiceSpeakingErr)
 	VoiceStateUpdate(handler HandlerVoiceStateUpdate, moreHandlers ...HandlerVoiceStateUpdate)
 	VoiceStateUpdateChan(handler chan *VoiceStateUpdate, moreHandlers ...chan *VoiceStateUpdate)
+	VoiceStateUpdateErr(handler HandlerVoiceStateUpdateErr, moreHandlers ...HandlerVoiceStateUpdateErr)
 	WebhooksUpdate(handler HandlerWebhooksUpdate, moreHandlers ...HandlerWebhooksUpdate)
 	WebhooksUpdateChan(handler chan *WebhooksUpdate, moreHandlers ...chan *WebhooksUpdate)
+	WebhooksUpdateErr(handler HandlerWebhooksUpdateErr, moreHandlers ...HandlerWebhooksUpdateErr)
 	WithCtrl(HandlerCtrl) SocketHandlerRegistrator
 	WithMiddleware(first Middleware, extra ...Middleware) SocketHandlerRegistrator
 }
@@ -4,6 +4,8 @@ package disgord
 
 import (
 	"testing"
+
+	"github.com/andersfylling/disgord/json"
 )
 
 func TestPrepareBox(t *testing.T) {
@@ -14,6 +16,25 @@ func TestPrepareBox(t *testing.T) {
 	})
 }
 
+func TestGuildMembersChunk_notFound(t *testing.T) {
+	// user ids above 2^53 can not be represented by a float64
+	data := []byte(`{"guild_id":"1","not_found":["9007199254740993",9007199254740995]}`)
+	evt := &GuildMembersChunk{}
+	if err := json.Unmarshal(data, evt); err != nil {
+		t.Fatal(err)
+	}
+
+	expects := []Snowflake{9007199254740993, 9007199254740995}
+	if len(evt.NotFound) != len(expects) {
+		t.Fatalf("expected %d user ids, got %+v", len(expects), evt.NotFound)
+	}
+	for i, userID := range expects {
+		if evt.NotFound[i] != userID {
+			t.Errorf("expected user id %d, got %d", userID, evt.NotFound[i])
+		}
+	}
+}
+
 // func TestChannelCreate_UnmarshalJSON(t *testing.T) {
 // 	channel := &Channel{}
 // 	evt := &ChannelCreate{}
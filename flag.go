@@ -32,8 +32,23 @@ const (
 	// ordering
 	OrderAscending // default when sorting
 	OrderDescending
+
+	// REST queue priority, see RESTPriority
+	PriorityHigh
+	PriorityLow
 )
 
+// Priority returns the REST priority given by the PriorityHigh or PriorityLow flag. PriorityHigh wins if both are set.
+func (f Flag) Priority() RESTPriority {
+	if (f & PriorityHigh) > 0 {
+		return RESTPriorityHigh
+	}
+	if (f & PriorityLow) > 0 {
+		return RESTPriorityLow
+	}
+	return RESTPriorityNormal
+}
+
 func mergeFlags(flags []Flag) (f Flag) {
 	for i := range flags {
 		f |= flags[i]
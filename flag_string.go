@@ -17,6 +17,8 @@ func _() {
 	_ = x[SortByChannelID-64]
 	_ = x[OrderAscending-128]
 	_ = x[OrderDescending-256]
+	_ = x[PriorityHigh-512]
+	_ = x[PriorityLow-1024]
 }
 
 const (
@@ -28,6 +30,8 @@ const (
 	_Flag_name_5 = "SortByChannelID"
 	_Flag_name_6 = "OrderAscending"
 	_Flag_name_7 = "OrderDescending"
+	_Flag_name_8 = "PriorityHigh"
+	_Flag_name_9 = "PriorityLow"
 )
 
 var (
@@ -53,6 +57,10 @@ func (i Flag) String() string {
 		return _Flag_name_6
 	case i == 256:
 		return _Flag_name_7
+	case i == 512:
+		return _Flag_name_8
+	case i == 1024:
+		return _Flag_name_9
 	default:
 		return "Flag(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
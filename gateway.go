@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/andersfylling/disgord/internal/gateway"
 	"github.com/andersfylling/disgord/internal/gateway/cmd"
@@ -26,6 +29,9 @@ type GatewayQueryBuilder interface {
 
 	Dispatch(name gatewayCmdName, payload gateway.CmdPayload) (unchandledGuildIDs []Snowflake, err error)
 
+	// RequestGuildMembers requests guild members over the gateway and waits for every chunk Discord sends back
+	RequestGuildMembers(payload *RequestGuildMembersPayload) (*GuildMembersResponse, error)
+
 	// Connect establishes a websocket connection to the discord API
 	Connect() error
 	StayConnectedUntilInterrupted() error
@@ -34,6 +40,12 @@ type GatewayQueryBuilder interface {
 	Disconnect() error
 	DisconnectOnInterrupt() error
 
+	// Reshard replaces the shards with a new set of shards without going offline
+	Reshard(shardCount uint, shardIDs ...uint) error
+
+	// RestartShards replaces every shard with a new connection without going offline
+	RestartShards() error
+
 	SocketHandlerRegistrator
 }
 
@@ -102,11 +114,13 @@ func (g gatewayQueryBuilder) Connect() (err error) {
 func (g gatewayQueryBuilder) Disconnect() (err error) {
 	fmt.Println() // to keep ^C on it's own line
 	g.client.log.Info("Closing Discord gateway connection")
-	close(g.client.dispatcher.shutdown)
+	// the dispatcher is closed after the shards such that ShardDisconnected events reaches the handlers
 	if err = g.client.shardManager.Disconnect(); err != nil {
 		g.client.log.Error(err)
+		close(g.client.dispatcher.shutdown)
 		return err
 	}
+	close(g.client.dispatcher.shutdown)
 	close(g.client.shutdownChan)
 	g.client.log.Info("Disconnected")
 
@@ -150,6 +164,35 @@ func (g gatewayQueryBuilder) StayConnectedUntilInterrupted() (err error) {
 	return g.Disconnect()
 }
 
+// Reshard creates a new set of shards with the given total shard count. The current shards stays connected
+// and keeps dispatching events until every new shard has received a Ready event, after which the new
+// shards take over. Use the context to abort the resharding, which keeps the current shards.
+//
+// If you run several instances of your bot, you must specify which shard IDs this instance should
+// handle. Otherwise every shard, [0, shardCount), is created locally.
+func (g gatewayQueryBuilder) Reshard(shardCount uint, shardIDs ...uint) error {
+	g.client.mu.RLock()
+	sharding := g.client.shardManager
+	g.client.mu.RUnlock()
+	if sharding == nil {
+		return errors.New("you must connect before you can reshard")
+	}
+
+	return sharding.Reshard(g.ctx, shardCount, shardIDs)
+}
+
+// RestartShards does a rolling restart of the shards, keeping the current shard setup. See Reshard.
+func (g gatewayQueryBuilder) RestartShards() error {
+	g.client.mu.RLock()
+	sharding := g.client.shardManager
+	g.client.mu.RUnlock()
+	if sharding == nil {
+		return errors.New("you must connect before you can restart the shards")
+	}
+
+	return sharding.Reshard(g.ctx, sharding.ShardCount(), sharding.ShardIDs())
+}
+
 // BotReady triggers a given callback when all shards has gotten their first Ready event
 // Warning: Do not call Client.Connect before this.
 func (g gatewayQueryBuilder) BotReady(cb func()) {
@@ -210,6 +253,179 @@ func (g gatewayQueryBuilder) Dispatch(name gatewayCmdName, payload gateway.CmdPa
 	return g.client.shardManager.Emit(string(name), payload)
 }
 
+// GuildMembersResponse holds the content of every GuildMembersChunk event sent by Discord in response
+// to a RequestGuildMembers command.
+type GuildMembersResponse struct {
+	Members   []*Member
+	Presences []*PresenceUpdate
+
+	// NotFound holds the user ids that were requested, but that are not members of the guild(s)
+	NotFound []Snowflake
+}
+
+// guildMembersChunkTimeout is how long RequestGuildMembers waits for the next chunk when the context has
+// no deadline, as Discord might never send the last chunk when the shard reconnects.
+var guildMembersChunkTimeout = time.Minute
+
+// RequestGuildMembers sends a RequestGuildMembers command and blocks until Discord has sent the last
+// GuildMembersChunk for every requested guild, the context is done or the client shuts down. Unless the
+// context has a deadline, an error is returned when Discord has not sent a chunk for a minute.
+//
+// The chunks are correlated to the request by the nonce. If the payload does not specify a nonce, a unique one
+// is generated. Note that the payload is not modified.
+func (g gatewayQueryBuilder) RequestGuildMembers(payload *RequestGuildMembersPayload) (*GuildMembersResponse, error) {
+	if payload == nil || len(payload.GuildIDs) == 0 {
+		return nil, errors.New("at least one guild id must be specified")
+	}
+	if len(payload.Nonce) > 32 {
+		return nil, errors.New("the nonce can not be longer than 32 bytes")
+	}
+	if g.client.rejectsEvent(EvtGuildMembersChunk) {
+		return nil, fmt.Errorf("can not wait for guild members while the %s event is rejected", EvtGuildMembersChunk)
+	}
+
+	p := *payload
+	if p.Nonce == "" {
+		p.Nonce = g.client.memberRequests.nonce()
+	}
+	request, err := g.client.memberRequests.add(p.Nonce, p.GuildIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer g.client.memberRequests.remove(p.Nonce)
+
+	unhandledGuildIDs, err := g.Dispatch(RequestGuildMembers, &p)
+	if err != nil {
+		return nil, err
+	}
+	if len(unhandledGuildIDs) > 0 {
+		return nil, fmt.Errorf("no local shard handles the guilds %v", unhandledGuildIDs)
+	}
+
+	var timeout <-chan time.Time
+	var timer *time.Timer
+	if _, hasDeadline := g.ctx.Deadline(); !hasDeadline {
+		timer = time.NewTimer(guildMembersChunkTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
+		select {
+		case <-request.done:
+			return &request.response, nil
+		case <-request.progress:
+			if timer != nil {
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(guildMembersChunkTimeout)
+			}
+		case <-timeout:
+			return nil, fmt.Errorf("no guild member chunk was received within %s", guildMembersChunkTimeout)
+		case <-g.ctx.Done():
+			return nil, g.ctx.Err()
+		case <-g.client.shutdownChan:
+			return nil, errors.New("client shut down before every guild member chunk was received")
+		}
+	}
+}
+
+// guildMembersRequests correlates GuildMembersChunk events with their RequestGuildMembers command
+type guildMembersRequests struct {
+	sync.Mutex
+	pending map[string]*guildMembersRequest
+	counter uint64
+}
+
+type guildMembersRequest struct {
+	// chunk count per guild, which is unknown until the first chunk arrives
+	chunkCount map[Snowflake]uint
+	received   map[Snowflake]uint
+
+	response GuildMembersResponse
+	done     chan struct{}
+	progress chan struct{} // notified for every chunk
+}
+
+func (r *guildMembersRequests) nonce() string {
+	r.Lock()
+	defer r.Unlock()
+	r.counter++
+	return "disgord:" + strconv.FormatUint(r.counter, 36)
+}
+
+func (r *guildMembersRequests) add(nonce string, guildIDs []Snowflake) (*guildMembersRequest, error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.pending == nil {
+		r.pending = make(map[string]*guildMembersRequest)
+	}
+	if _, exists := r.pending[nonce]; exists {
+		return nil, fmt.Errorf("there is already a pending guild members request with the nonce %s", nonce)
+	}
+
+	request := &guildMembersRequest{
+		chunkCount: make(map[Snowflake]uint, len(guildIDs)),
+		received:   make(map[Snowflake]uint, len(guildIDs)),
+		done:       make(chan struct{}),
+		progress:   make(chan struct{}, 1),
+	}
+	for _, guildID := range guildIDs {
+		request.received[guildID] = 0
+	}
+	r.pending[nonce] = request
+	return request, nil
+}
+
+func (r *guildMembersRequests) remove(nonce string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.pending, nonce)
+}
+
+// collect adds the content of a chunk to the matching request. Events are dispatched concurrently,
+// so the chunks are counted instead of relying on the last chunk arriving last.
+func (r *guildMembersRequests) collect(evt *GuildMembersChunk) {
+	if evt.Nonce == "" {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	request, ok := r.pending[evt.Nonce]
+	if !ok {
+		return
+	}
+	if _, ok = request.received[evt.GuildID]; !ok {
+		return
+	}
+
+	request.received[evt.GuildID]++
+	request.chunkCount[evt.GuildID] = evt.ChunkCount
+	request.response.Members = append(request.response.Members, evt.Members...)
+	request.response.Presences = append(request.response.Presences, evt.Presences...)
+	for _, userID := range evt.NotFound {
+		if !userID.IsZero() {
+			request.response.NotFound = append(request.response.NotFound, userID)
+		}
+	}
+
+	select {
+	case request.progress <- struct{}{}:
+	default:
+	}
+
+	for guildID, received := range request.received {
+		if chunkCount, known := request.chunkCount[guildID]; !known || received < chunkCount {
+			return
+		}
+	}
+
+	delete(r.pending, evt.Nonce)
+	close(request.done)
+}
+
 // Get Returns an object with a single valid WSS URL, which the Client can use for Connecting.
 // Clients should cacheLink this value and only call this endpoint to retrieve a new URL if they are unable to
 // properly establish a connection using the cached version of the URL.
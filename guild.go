@@ -671,6 +671,7 @@ type GuildQueryBuilder interface {
 	// TODO: For GetMembers, it might sense to have the option for a function to filter before each member ends up deep copied.
 	// TODO-2: This could be much more performant in larger guilds where this is needed.
 	GetMembers(params *GetMembersParams, flags ...Flag) ([]*Member, error)
+	IterateMembers(flags ...Flag) *MemberIterator
 	UpdateBuilder(flags ...Flag) UpdateGuildBuilder
 	Delete(flags ...Flag) error
 
@@ -685,6 +686,7 @@ type GuildQueryBuilder interface {
 	KickVoiceParticipant(userID Snowflake) error
 	SetCurrentUserNick(nick string, flags ...Flag) (newNick string, err error)
 	GetBans(flags ...Flag) ([]*Ban, error)
+	IterateBans(flags ...Flag) *BanIterator
 	GetBan(userID Snowflake, flags ...Flag) (*Ban, error)
 	UnbanUser(userID Snowflake, reason string, flags ...Flag) error
 	// TODO: For GetRoles, it might sense to have the option for a function to filter before each role ends up deep copied.
@@ -710,6 +712,7 @@ type GuildQueryBuilder interface {
 	UpdateEmbedBuilder(flags ...Flag) UpdateGuildEmbedBuilder
 	GetVanityURL(flags ...Flag) (*PartialInvite, error)
 	GetAuditLogs(flags ...Flag) GuildAuditLogsBuilder
+	IterateAuditLogs(params *IterateAuditLogsParams, flags ...Flag) *AuditLogIterator
 
 	VoiceChannel(channelID Snowflake) VoiceChannelQueryBuilder
 
@@ -994,8 +997,25 @@ func (g guildQueryBuilder) SetCurrentUserNick(nick string, flags ...Flag) (newNi
 
 // GetBans returns an array of ban objects for the Users banned from this guild. Requires the 'BAN_MEMBERS' permission.
 func (g guildQueryBuilder) GetBans(flags ...Flag) ([]*Ban, error) {
+	return g.getBans(nil, flags...)
+}
+
+type getGuildBansParams struct {
+	Before Snowflake `urlparam:"before,omitempty"`
+	After  Snowflake `urlparam:"after,omitempty"`
+	Limit  int       `urlparam:"limit,omitempty"` // 1000 is default and max
+}
+
+var _ URLQueryStringer = (*getGuildBansParams)(nil)
+
+func (g guildQueryBuilder) getBans(params *getGuildBansParams, flags ...Flag) ([]*Ban, error) {
+	query := ""
+	if params != nil {
+		query = params.URLQueryString()
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
-		Endpoint: endpoint.GuildBans(g.gid),
+		Endpoint: endpoint.GuildBans(g.gid) + query,
 		Ctx:      g.ctx,
 	}, flags)
 	r.factory = func() interface{} {
@@ -1320,7 +1340,7 @@ func (g guildQueryBuilder) GetVanityURL(flags ...Flag) (*PartialInvite, error) {
 // GetAuditLogs Returns an audit log object for the guild. Requires the 'VIEW_AUDIT_LOG' permission.
 // Note that this request will _always_ send a REST request, regardless of you calling IgnoreCache or not.
 func (g guildQueryBuilder) GetAuditLogs(flags ...Flag) GuildAuditLogsBuilder {
-	builder := &guildAuditLogsBuilder{}
+	builder := &guildAuditLogsBuilder{guild: g}
 	builder.r.itemFactory = auditLogFactory
 	builder.r.flags = flags
 	builder.r.IgnoreCache().setup(g.client.req, &httd.Request{
@@ -24,6 +24,24 @@ func (g *GetMessagesParams) URLQueryString() string {
 	return params.URLQueryString()
 }
 
+func (g *getGuildBansParams) URLQueryString() string {
+	params := make(urlQuery)
+
+	if !(g.Before == 0) {
+		params["before"] = g.Before
+	}
+
+	if !(g.After == 0) {
+		params["after"] = g.After
+	}
+
+	if !(g.Limit == 0) {
+		params["limit"] = g.Limit
+	}
+
+	return params.URLQueryString()
+}
+
 func (g *getGuildMembersParams) URLQueryString() string {
 	params := make(urlQuery)
 
@@ -0,0 +1,39 @@
+package event
+
+// Events in this file are not sent by Discord. They are emitted by Disgord to signal changes in
+// the state of the library, and are handled by the reactor the same way as Discord events.
+// Note that they are not affected by intents, and are never seen by the cache.
+
+// ShardConnected Sent when a shard has identified and received a Ready event from Discord.
+const ShardConnected = "DISGORD_SHARD_CONNECTED"
+
+// ShardDisconnected Sent when a shard has lost its connection to Discord. The shard will try to reconnect
+// unless the disconnect was requested or Discord rejected the connection.
+const ShardDisconnected = "DISGORD_SHARD_DISCONNECTED"
+
+// ShardResumed Sent when a shard has resumed a previous session and Discord replied with a Resumed event.
+const ShardResumed = "DISGORD_SHARD_RESUMED"
+
+// ShardScaled Sent when the shards have been scaled, either due to Discord requiring more shards
+// or a manual re-shard. The payload holds the new total shard count and the local shard IDs.
+const ShardScaled = "DISGORD_SHARD_SCALED"
+
+// VoiceSpeaking Sent when a user in a voice channel that the bot is connected to starts speaking, which
+// links the SSRC of their audio to the user. Only sent for voice connections created by Disgord.
+const VoiceSpeaking = "DISGORD_VOICE_SPEAKING"
+
+// VoiceClientDisconnect Sent when a user leaves a voice channel that the bot is connected to. Only sent for
+// voice connections created by Disgord.
+const VoiceClientDisconnect = "DISGORD_VOICE_CLIENT_DISCONNECT"
+
+// Disgord returns every event in this file, as they are not listed by All.
+func Disgord() []string {
+	return []string{
+		ShardConnected,
+		ShardDisconnected,
+		ShardResumed,
+		ShardScaled,
+		VoiceSpeaking,
+		VoiceClientDisconnect,
+	}
+}
@@ -100,9 +100,19 @@ type config struct {
 
 	discordErrListener discordErrListener
 
+	// recorder records the incoming and outgoing packets, if set
+	recorder *Recorder
+
+	// onDisconnect is called every time the client disconnects, regardless if
+	// the websocket connection was already closed.
+	onDisconnect func()
+
 	// messageQueueLimit number of outgoing messages that can be queued and sent correctly.
 	messageQueueLimit uint
 
+	// stopReconnecting stops any ongoing reconnect attempts once closed, if set.
+	stopReconnecting <-chan interface{}
+
 	SystemShutdown chan interface{}
 }
 
@@ -150,6 +160,9 @@ type client struct {
 	isEmitting        atomic.Bool // has the go routine started
 	onceChannels      onceChannels
 
+	// goroutines tracks the receiver, emitter and heartbeats of the current connection
+	goroutines *sync.WaitGroup
+
 	isRestarting atomic.Bool
 
 	// identify timeout on invalid session
@@ -267,7 +280,6 @@ func (c *client) IsDisconnected() bool {
 
 func (c *client) disconnect() (err error) {
 	c.Lock()
-	defer c.Unlock()
 	alreadyDisconnected := c.conn.Disconnected() || !c.haveConnectedOnce.Load() || c.cancel == nil
 
 	// stop emitter, receiver and behaviors
@@ -283,6 +295,13 @@ func (c *client) disconnect() (err error) {
 	// c.Emit(event.Close, nil)
 	// dont use emit, such that we can call shutdown at the same time as Disconnect (See Shutdown())
 	c.isConnected.Store(false)
+	c.Unlock()
+
+	// the connection might have been closed by Discord, so this is called
+	// even when the websocket connection was already closed
+	if c.conf.onDisconnect != nil {
+		c.conf.onDisconnect()
+	}
 
 	if alreadyDisconnected {
 		return errors.New("already disconnected")
@@ -342,6 +361,9 @@ func (c *client) reconnectLoop() (err error) {
 		case <-c.SystemShutdown:
 			c.log.Debug(c.getLogPrefix(), "stopping reconnect attempt", try)
 			return
+		case <-c.conf.stopReconnecting:
+			c.log.Debug(c.getLogPrefix(), "stopping reconnect attempt", try)
+			return
 		}
 
 		if delay > 5*60*time.Second {
@@ -352,6 +374,43 @@ func (c *client) reconnectLoop() (err error) {
 	return
 }
 
+// startGoroutines runs the receiver, emitter and heartbeats of a new connection. On reconnects the
+// goroutines of the previous connection might still be shutting down, so they are waited for first.
+func (c *client) startGoroutines(ctx context.Context) {
+	c.Lock()
+	previous := c.goroutines
+	current := &sync.WaitGroup{}
+	c.goroutines = current
+	c.Unlock()
+
+	if previous != nil {
+		stopped := make(chan struct{})
+		go func() {
+			previous.Wait()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	current.Add(3)
+	go func() {
+		defer current.Done()
+		c.receiver(ctx)
+	}()
+	go func() {
+		defer current.Done()
+		c.emitter(ctx)
+	}()
+	go func() {
+		defer current.Done()
+		c.prepareHeartbeating(ctx)
+	}()
+}
+
 //////////////////////////////////////////////////////
 //
 // EMITTING / DISPATCHING
@@ -419,6 +478,7 @@ func (c *client) emitter(ctx context.Context) {
 		// save to file
 		// build tag: disgord_diagnosews
 		saveOutgoingPacket(c, msg)
+		c.conf.recorder.recordOutgoing(c.ShardID, msg)
 
 		if err := c.conn.WriteJSON(msg); err != nil {
 			once.Do(cancel)
@@ -520,6 +580,10 @@ func (c *client) receiver(ctx context.Context) {
 					case 4008:
 						c.log.Error(c.getLogPrefix(), "Woah nelly! You're sending payloads to us too quickly. Slow it down! You will be disconnected on receiving this.")
 						_ = c.Disconnect()
+					case discordErrShardScalingRequired:
+						c.log.Error(c.getLogPrefix(), "Discord requires more shards, this shard will be replaced.")
+						_ = c.Disconnect()
+						reconnect = false
 					case 4010:
 						c.log.Error(c.getLogPrefix(), "You sent us an invalid shard when identifying.")
 						_ = c.Disconnect()
@@ -545,9 +609,9 @@ func (c *client) receiver(ctx context.Context) {
 					}
 					switch closeErr.code {
 					case 4006:
-						// session is no longer valid, and should do a normal identify
+						// session is no longer valid, and the reconnect identifies instead of resuming,
+						// see the close operations of the voice client
 						c.log.Debug(c.getLogPrefix(), "discord sent a 4006 websocket code and the bot will now reconnect")
-						_ = c.Disconnect()
 					case 4014:
 						// Disconnected: Either the channel was deleted or you were kicked. Should not reconnect.
 						// https://discord.com/developers/docs/topics/opcodes-and-status-codes#voice-voice-close-event-codes
@@ -594,9 +658,14 @@ func (c *client) receiver(ctx context.Context) {
 		// save to file
 		// build tag: disgord_diagnosews
 		saveIncomingPacker(c, evt, packet)
+		c.conf.recorder.recordIncoming(c.ShardID, packet)
 
 		// notify listeners
-		c.receiveChan <- evt
+		select {
+		case c.receiveChan <- evt:
+		case <-ctx.Done():
+			c.poolDiscordPkt.Put(evt)
+		}
 	}
 }
 
@@ -59,13 +59,16 @@ func NewEventClient(shardID uint, conf *EvtConfig) (client *EvtClient, err error
 		eventChan:    eChan,
 	}
 	client.client, err = newClient(shardID, &config{
-		Logger:            conf.Logger,
-		Endpoint:          conf.Endpoint,
-		DiscordPktPool:    conf.DiscordPktPool,
-		HTTPClient:        conf.HTTPClient,
-		conn:              conf.conn,
-		messageQueueLimit: conf.MessageQueueLimit,
-		SystemShutdown:    conf.SystemShutdown,
+		Logger:             conf.Logger,
+		Endpoint:           conf.Endpoint,
+		DiscordPktPool:     conf.DiscordPktPool,
+		HTTPClient:         conf.HTTPClient,
+		conn:               conf.conn,
+		messageQueueLimit:  conf.MessageQueueLimit,
+		SystemShutdown:     conf.SystemShutdown,
+		discordErrListener: conf.discordErrListener,
+		recorder:           conf.Recorder,
+		onDisconnect:       client.onDisconnect,
 	}, client.internalConnect)
 	if err != nil {
 		return nil, err
@@ -122,6 +125,9 @@ type EvtConfig struct {
 
 	discordErrListener discordErrListener
 
+	// Recorder records the gateway traffic of this shard, if set. See Recorder.
+	Recorder *Recorder
+
 	Presence *UpdateStatusPayload
 
 	// Endpoint for establishing socket connection. Either endpoints, `Gateway` or `Gateway Bot`, is used to retrieve
@@ -171,6 +177,12 @@ type EvtClient struct {
 	sessionID      string
 	sequenceNumber atomic.Uint32
 
+	// state is a ShardState
+	state atomic.Uint32
+
+	// muted shards does not dispatch any events. Used while resharding.
+	muted atomic.Bool
+
 	rdyPool *sync.Pool
 
 	identity *evtIdentity
@@ -290,10 +302,16 @@ func (c *EvtClient) onDiscordEvent(v interface{}) (err error) {
 		return
 	}
 
+	var lifecycleEvt string
 	if p.EventName == event.Ready {
 		if err = c.onReady(p); err != nil {
 			return err
 		}
+		c.setState(ShardStateConnected)
+		lifecycleEvt = evt.ShardConnected
+	} else if p.EventName == event.Resumed {
+		c.setState(ShardStateConnected)
+		lifecycleEvt = evt.ShardResumed
 	}
 	//} else if p.EventName == event.Resumed {
 	//	if ch := c.onceChannels.Acquire(opcode.EventReadyResumed); ch != nil {
@@ -303,17 +321,22 @@ func (c *EvtClient) onDiscordEvent(v interface{}) (err error) {
 	//	}
 	//}
 
-	if !c.eventOfInterest(p.EventName) {
+	if c.muted.Load() {
 		return nil
 	}
 
-	// dispatch event through out the Disgord system
-	c.eventChan <- &Event{
-		Name:    p.EventName,
-		Data:    p.Data,
-		ShardID: c.ShardID,
+	if c.eventOfInterest(p.EventName) {
+		// dispatch event through out the Disgord system
+		c.eventChan <- &Event{
+			Name:    p.EventName,
+			Data:    p.Data,
+			ShardID: c.ShardID,
+		}
 	}
 
+	if lifecycleEvt != "" {
+		c.emitLifecycleEvent(lifecycleEvt, nil)
+	}
 	return nil
 } // end onDiscordEvent
 
@@ -415,6 +438,7 @@ func (c *EvtClient) internalConnect() (evt interface{}, err error) {
 	var sessionCtx context.Context
 	sessionCtx, c.cancel = context.WithCancel(context.Background())
 
+	c.setState(ShardStateWaitingInQueue)
 	err = c.evtConf.connectQueue(c.ShardID, func() error {
 		sentIdentifyResume := make(chan interface{})
 		c.onceChannels.Add(opcode.EventIdentify, sentIdentifyResume)
@@ -442,6 +466,9 @@ func (c *EvtClient) internalConnect() (evt interface{}, err error) {
 		}
 		return nil
 	})
+	if err != nil {
+		c.setState(ShardStateDisconnected)
+	}
 	return nil, err
 }
 
@@ -454,10 +481,8 @@ func (c *EvtClient) openConnection(ctx context.Context) error {
 	// we can now interact with Discord
 	c.haveConnectedOnce.Store(true)
 	c.isConnected.Store(true)
-	go c.receiver(ctx)
-	go c.emitter(ctx)
+	c.startGoroutines(ctx)
 	go c.startBehaviors(ctx)
-	go c.prepareHeartbeating(ctx)
 	go func() {
 		select {
 		case <-ctx.Done():
@@ -469,6 +494,15 @@ func (c *EvtClient) openConnection(ctx context.Context) error {
 	return nil
 }
 
+// onDisconnect notifies the user that the shard lost its connection. A shard that never
+// opened a websocket connection, or has already notified, is ignored.
+func (c *EvtClient) onDisconnect() {
+	switch ShardState(c.state.Swap(uint32(ShardStateDisconnected))) {
+	case ShardStateIdentifying, ShardStateResuming, ShardStateConnected:
+		c.emitLifecycleEvent(evt.ShardDisconnected, nil)
+	}
+}
+
 func (c *EvtClient) eventOfInterest(name string) bool {
 	for i := range c.ignoreEvents {
 		if c.ignoreEvents[i] == name {
@@ -485,6 +519,7 @@ func (c *EvtClient) sendHelloPacket() {
 	c.RUnlock()
 	sequence := c.sequenceNumber.Load()
 
+	c.setState(ShardStateResuming)
 	err := c.emit(event.Resume, &evtResume{token, session, sequence})
 	if err != nil {
 		c.log.Error(c.getLogPrefix(), err)
@@ -503,6 +538,7 @@ func sendIdentityPacket(invalidSession bool, c *EvtClient) (err error) {
 	*id = *c.identity
 	// copy it to avoid data race
 	c.idMu.RUnlock()
+	c.setState(ShardStateIdentifying)
 	err = c.emit(event.Identify, id)
 
 	if !invalidSession {
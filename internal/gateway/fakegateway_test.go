@@ -0,0 +1,114 @@
+// +build !integration
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord/disgordtest"
+	"github.com/andersfylling/disgord/internal/logger"
+)
+
+// fakeConn passes the close codes of a disgordtest.FakeConn to the shard as a *CloseErr
+type fakeConn struct {
+	*disgordtest.FakeConn
+}
+
+var _ Conn = (*fakeConn)(nil)
+
+func (c *fakeConn) Read(ctx context.Context) (packet []byte, err error) {
+	packet, err = c.FakeConn.Read(ctx)
+	var closeErr *disgordtest.CloseError
+	if errors.As(err, &closeErr) {
+		return nil, &CloseErr{code: closeErr.Code, info: closeErr.Reason}
+	}
+	return packet, err
+}
+
+func TestFakeGateway(t *testing.T) {
+	gw := disgordtest.NewFakeGateway()
+	defer gw.Stop()
+
+	eChan := make(chan *Event, 10)
+	shutdown := make(chan interface{})
+	defer close(shutdown)
+
+	client, err := NewEventClient(0, &EvtConfig{
+		BotToken:   "test",
+		Endpoint:   "ws://fake",
+		Logger:     &logger.Empty{},
+		ShardCount: 1,
+		DiscordPktPool: &sync.Pool{
+			New: func() interface{} {
+				return &DiscordPacket{}
+			},
+		},
+		connectQueue: func(shardID uint, cb func() error) error {
+			return cb()
+		},
+		EventChan:      eChan,
+		conn:           &fakeConn{gw.Conn()},
+		SystemShutdown: shutdown,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.timeoutMultiplier = 0
+
+	expect := func(t *testing.T, names ...string) {
+		t.Helper()
+		for _, name := range names {
+			select {
+			case evt := <-eChan:
+				if evt.Name != name {
+					t.Fatalf("expected event %s, got %s", name, evt.Name)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for %s", name)
+			}
+		}
+	}
+
+	if err = client.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "READY", "DISGORD_SHARD_CONNECTED")
+
+	if err = gw.Dispatch(0, "MESSAGE_CREATE", map[string]interface{}{"id": "1", "content": "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "MESSAGE_CREATE")
+	if err = gw.Dispatch(1, "MESSAGE_CREATE", nil); err == nil {
+		t.Error("expected an error when dispatching to a shard without a session")
+	}
+
+	t.Run("reconnect", func(t *testing.T) {
+		gw.RequestReconnect()
+		expect(t, "DISGORD_SHARD_DISCONNECTED", "RESUMED", "DISGORD_SHARD_RESUMED")
+		if gw.Resumes() != 1 {
+			t.Errorf("expected 1 resume, got %d", gw.Resumes())
+		}
+	})
+
+	t.Run("close code", func(t *testing.T) {
+		gw.Close(4000, "Unknown error")
+		expect(t, "DISGORD_SHARD_DISCONNECTED", "RESUMED", "DISGORD_SHARD_RESUMED")
+		if gw.Resumes() != 2 {
+			t.Errorf("expected 2 resumes, got %d", gw.Resumes())
+		}
+	})
+
+	t.Run("invalid session", func(t *testing.T) {
+		gw.InvalidateSessions(false)
+		expect(t, "READY", "DISGORD_SHARD_CONNECTED")
+		if gw.Identifies() != 2 {
+			t.Errorf("expected 2 identifies, got %d", gw.Identifies())
+		}
+	})
+
+	_ = client.Disconnect()
+}
@@ -82,6 +82,21 @@ type VoiceSessionDescription struct {
 	SecretKey [32]byte `json:"secret_key"`
 }
 
+// VoiceSpeaking is sent when a user starts or stops speaking, and links the SSRC of the user's audio
+// packets to the user id.
+type VoiceSpeaking struct {
+	UserID Snowflake `json:"user_id"`
+	SSRC   uint32    `json:"ssrc"`
+
+	// Speaking is a bitmask, where 1 is voice, 2 is soundshare and 4 is priority speaker.
+	Speaking uint8 `json:"speaking"`
+}
+
+// VoiceClientDisconnect is sent when a user leaves the voice channel.
+type VoiceClientDisconnect struct {
+	UserID Snowflake `json:"user_id"`
+}
+
 type voiceIdentify struct {
 	GuildID   Snowflake `json:"server_id"` // Yay for inconsistency
 	UserID    Snowflake `json:"user_id"`
@@ -89,6 +104,12 @@ type voiceIdentify struct {
 	Token     string    `json:"token"`
 }
 
+type voiceResume struct {
+	GuildID   Snowflake `json:"server_id"`
+	SessionID string    `json:"session_id"`
+	Token     string    `json:"token"`
+}
+
 //////////////////////////////////////////////////////
 //
 // EVENT SPECIFIC
@@ -0,0 +1,162 @@
+package gateway
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/andersfylling/disgord/json"
+	"go.uber.org/atomic"
+)
+
+// RecordedPacket is a single gateway packet in a recording. A recording is newline delimited json, where
+// every line is a RecordedPacket. The packets of every shard are written to the same recording, in the
+// order they were sent or received.
+type RecordedPacket struct {
+	// Seq is the position of the packet in the recording, starting at 1
+	Seq uint64 `json:"seq"`
+
+	// Time is when the packet was sent or received, in unix nano
+	Time int64 `json:"time"`
+
+	ShardID  uint            `json:"shard_id"`
+	Outgoing bool            `json:"outgoing,omitempty"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// Recorder writes the gateway traffic of every shard into a single recording. Use Start and Stop to
+// toggle recording at runtime. The bot token is removed from outgoing identify and resume packets.
+//
+// The recording can be replayed using Replay.
+type Recorder struct {
+	sync.Mutex
+	w   *bufio.Writer
+	seq uint64
+	err error
+
+	recording atomic.Bool
+}
+
+// NewRecorder creates a stopped recorder that writes to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: bufio.NewWriter(w)}
+}
+
+// Start records every incoming and outgoing gateway packet until Stop is called.
+func (r *Recorder) Start() {
+	r.recording.Store(true)
+}
+
+// Stop stops recording and flushes the recorded packets to the underlying writer.
+func (r *Recorder) Stop() error {
+	r.recording.Store(false)
+
+	r.Lock()
+	defer r.Unlock()
+	if r.err == nil {
+		r.err = r.w.Flush()
+	}
+	return r.err
+}
+
+// Recording returns true while packets are being recorded.
+func (r *Recorder) Recording() bool {
+	return r.recording.Load()
+}
+
+// Err returns the first error that occurred while writing the recording.
+func (r *Recorder) Err() error {
+	r.Lock()
+	defer r.Unlock()
+	return r.err
+}
+
+func (r *Recorder) recordIncoming(shardID uint, packet []byte) {
+	if r == nil || !r.recording.Load() {
+		return
+	}
+
+	// the packet buffer might be reused by the websocket library
+	data := make([]byte, len(packet))
+	copy(data, packet)
+	r.write(shardID, false, data)
+}
+
+func (r *Recorder) recordOutgoing(shardID uint, packet *clientPacket) {
+	if r == nil || !r.recording.Load() {
+		return
+	}
+
+	p := *packet
+	switch t := p.Data.(type) {
+	case *evtIdentity:
+		identity := *t
+		identity.Token = ""
+		p.Data = &identity
+	case *evtResume:
+		resume := *t
+		resume.Token = ""
+		p.Data = &resume
+	}
+
+	data, err := json.Marshal(&p)
+	if err != nil {
+		r.Lock()
+		if r.err == nil {
+			r.err = err
+		}
+		r.Unlock()
+		return
+	}
+	r.write(shardID, true, data)
+}
+
+func (r *Recorder) write(shardID uint, outgoing bool, data []byte) {
+	r.Lock()
+	defer r.Unlock()
+	if r.err != nil {
+		return
+	}
+
+	r.seq++
+	line, err := json.Marshal(&RecordedPacket{
+		Seq:      r.seq,
+		Time:     time.Now().UnixNano(),
+		ShardID:  shardID,
+		Outgoing: outgoing,
+		Data:     data,
+	})
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	if _, err = r.w.Write(append(line, '\n')); err != nil {
+		r.err = err
+	}
+}
+
+// ReadRecording parses a recording created by a Recorder.
+func ReadRecording(r io.Reader) (packets []*RecordedPacket, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024) // guild create events can be huge
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		packet := &RecordedPacket{}
+		if err = json.Unmarshal(line, packet); err != nil {
+			return nil, err
+		}
+		if len(packets) > 0 && packets[len(packets)-1].Seq >= packet.Seq {
+			return nil, errors.New("recording is not ordered by sequence")
+		}
+		packets = append(packets, packet)
+	}
+
+	return packets, scanner.Err()
+}
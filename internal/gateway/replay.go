@@ -0,0 +1,215 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/andersfylling/disgord/json"
+)
+
+// Replay feeds a recording into the shards instead of connecting to Discord. This allows issues seen in
+// production to be reproduced, and regression tests to be written from real gateway traffic.
+//
+// Speed decides the timing between the packets: 1 keeps the original timing, 2 replays twice as fast,
+// and 0 replays the packets without any delay.
+type Replay struct {
+	sync.Mutex
+	recording []*RecordedPacket
+	speed     float64
+	conns     map[uint]*ReplayConn
+}
+
+// NewReplay parses a recording from a Recorder. See Replay.
+func NewReplay(recording io.Reader, speed float64) (*Replay, error) {
+	if speed < 0 {
+		return nil, errors.New("replay speed can not be negative")
+	}
+
+	packets, err := ReadRecording(recording)
+	if err != nil {
+		return nil, err
+	}
+	return &Replay{
+		recording: packets,
+		speed:     speed,
+		conns:     make(map[uint]*ReplayConn),
+	}, nil
+}
+
+// ShardIDs returns the ids of the shards found in the recording.
+func (r *Replay) ShardIDs() (shardIDs []uint) {
+	seen := make(map[uint]bool)
+	for _, packet := range r.recording {
+		if !seen[packet.ShardID] {
+			seen[packet.ShardID] = true
+			shardIDs = append(shardIDs, packet.ShardID)
+		}
+	}
+	sort.Slice(shardIDs, func(i, j int) bool {
+		return shardIDs[i] < shardIDs[j]
+	})
+	return shardIDs
+}
+
+// Conn returns the connection that replays the incoming packets of the given shard.
+func (r *Replay) Conn(shardID uint) *ReplayConn {
+	r.Lock()
+	defer r.Unlock()
+
+	if conn, ok := r.conns[shardID]; ok {
+		return conn
+	}
+
+	conn := NewReplayConn(r.recording, shardID, r.speed)
+	r.conns[shardID] = conn
+	return conn
+}
+
+// Done is closed once every shard in the recording has replayed all of its incoming packets.
+func (r *Replay) Done() <-chan struct{} {
+	done := make(chan struct{})
+	shardIDs := r.ShardIDs()
+	go func() {
+		for _, id := range shardIDs {
+			<-r.Conn(id).Done()
+		}
+		close(done)
+	}()
+	return done
+}
+
+// gatewayBot is used instead of asking Discord for the shard setup.
+func (r *Replay) gatewayBot() *GatewayBot {
+	bot := &GatewayBot{Gateway: Gateway{URL: "ws://replay"}}
+	for _, id := range r.ShardIDs() {
+		if id >= bot.Shards {
+			bot.Shards = id + 1
+		}
+	}
+	if bot.Shards == 0 {
+		bot.Shards = 1
+	}
+	return bot
+}
+
+// ReplayConn is a Conn that returns the incoming packets of a single shard from a recording. Outgoing packets
+// are not sent anywhere, but can be inspected using Written.
+//
+// A reconnect continues from the next packet, so a recording that holds several sessions is replayed as is.
+type ReplayConn struct {
+	sync.Mutex
+	packets []*RecordedPacket
+	speed   float64
+
+	next         int
+	lastTime     int64
+	written      []json.RawMessage
+	disconnected bool
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+var _ Conn = (*ReplayConn)(nil)
+
+// NewReplayConn creates a connection that replays the incoming packets of the given shard. See Replay.
+func NewReplayConn(recording []*RecordedPacket, shardID uint, speed float64) *ReplayConn {
+	conn := &ReplayConn{
+		speed:        speed,
+		disconnected: true,
+		done:         make(chan struct{}),
+	}
+	for _, packet := range recording {
+		if packet.ShardID == shardID && !packet.Outgoing {
+			conn.packets = append(conn.packets, packet)
+		}
+	}
+	return conn
+}
+
+func (c *ReplayConn) Open(_ context.Context, _ string, _ http.Header) error {
+	c.Lock()
+	defer c.Unlock()
+	c.disconnected = false
+	return nil
+}
+
+func (c *ReplayConn) Close() error {
+	c.Lock()
+	defer c.Unlock()
+	c.disconnected = true
+	return nil
+}
+
+func (c *ReplayConn) Disconnected() bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.disconnected
+}
+
+func (c *ReplayConn) WriteJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if c.disconnected {
+		return errors.New("replay connection is closed")
+	}
+	c.written = append(c.written, data)
+	return nil
+}
+
+// Read returns the next incoming packet once the recorded delay has passed. When the recording
+// has been replayed, Read blocks until the context is done.
+func (c *ReplayConn) Read(ctx context.Context) (packet []byte, err error) {
+	c.Lock()
+	if c.next >= len(c.packets) {
+		c.Unlock()
+		c.doneOnce.Do(func() { close(c.done) })
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	p := c.packets[c.next]
+	var delay time.Duration
+	if c.lastTime > 0 && c.speed > 0 {
+		delay = time.Duration(float64(p.Time-c.lastTime) / c.speed)
+	}
+	c.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	c.Lock()
+	c.next++
+	c.lastTime = p.Time
+	c.Unlock()
+	return p.Data, nil
+}
+
+// Written returns the packets written to the connection, such as identify and heartbeats.
+func (c *ReplayConn) Written() []json.RawMessage {
+	c.Lock()
+	defer c.Unlock()
+
+	written := make([]json.RawMessage, len(c.written))
+	copy(written, c.written)
+	return written
+}
+
+// Done is closed once every incoming packet has been replayed.
+func (c *ReplayConn) Done() <-chan struct{} {
+	return c.done
+}
@@ -0,0 +1,134 @@
+// +build !integration
+
+package gateway
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord/internal/gateway/opcode"
+	"github.com/andersfylling/disgord/internal/logger"
+	"github.com/andersfylling/disgord/json"
+)
+
+func TestRecorder(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	recorder := NewRecorder(buffer)
+
+	recorder.recordIncoming(0, []byte(`{"op":11}`))
+	recorder.Start()
+	recorder.recordIncoming(1, []byte(`{"op":10,"d":{"heartbeat_interval":41250}}`))
+	recorder.recordOutgoing(1, &clientPacket{Op: opcode.EventIdentify, Data: &evtIdentity{Token: "secret"}})
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	recorder.recordIncoming(0, []byte(`{"op":11}`))
+
+	packets, err := ReadRecording(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packets) != 2 {
+		t.Fatalf("expected 2 recorded packets, got %d", len(packets))
+	}
+	if packets[0].Seq != 1 || packets[0].Outgoing || packets[0].ShardID != 1 {
+		t.Errorf("incorrect incoming packet: %+v", packets[0])
+	}
+	if packets[1].Seq != 2 || !packets[1].Outgoing {
+		t.Errorf("incorrect outgoing packet: %+v", packets[1])
+	}
+	if strings.Contains(string(packets[1].Data), "secret") {
+		t.Error("the bot token must not be recorded")
+	}
+}
+
+func TestReplay(t *testing.T) {
+	f, err := os.Open("testdata/replay.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	replay, err := NewReplay(f, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bot := replay.gatewayBot(); bot.Shards != 1 {
+		t.Errorf("expected 1 shard, got %d", bot.Shards)
+	}
+
+	eChan := make(chan *Event, 10)
+	shutdown := make(chan interface{})
+	defer close(shutdown)
+
+	client, err := NewEventClient(0, &EvtConfig{
+		BotToken:   "test",
+		Endpoint:   "ws://replay",
+		Logger:     &logger.Empty{},
+		ShardCount: 1,
+		DiscordPktPool: &sync.Pool{
+			New: func() interface{} {
+				return &DiscordPacket{}
+			},
+		},
+		connectQueue: func(shardID uint, cb func() error) error {
+			return cb()
+		},
+		EventChan:      eChan,
+		conn:           replay.Conn(0),
+		SystemShutdown: shutdown,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	expects := []string{"READY", "DISGORD_SHARD_CONNECTED", "GUILD_CREATE", "MESSAGE_CREATE"}
+	for _, name := range expects {
+		select {
+		case evt := <-eChan:
+			if evt.Name != name {
+				t.Fatalf("expected event %s, got %s", name, evt.Name)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s", name)
+		}
+	}
+
+	select {
+	case <-replay.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the replay should be done")
+	}
+
+	// the replay does not wait for the identify packet to be sent
+	identified := func() bool {
+		for _, written := range replay.Conn(0).Written() {
+			var packet struct {
+				Op opcode.OpCode `json:"op"`
+			}
+			if err := json.Unmarshal(written, &packet); err != nil {
+				t.Fatal(err)
+			}
+			if packet.Op == opcode.EventIdentify {
+				return true
+			}
+		}
+		return false
+	}
+	deadline := time.Now().Add(time.Second)
+	for !identified() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the shard to identify")
+		}
+		<-time.After(10 * time.Millisecond)
+	}
+
+	_ = client.Disconnect()
+}
@@ -0,0 +1,101 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/andersfylling/disgord/json"
+)
+
+// ShardState describes what a shard is currently doing with its websocket connection.
+type ShardState uint32
+
+const (
+	ShardStateDisconnected ShardState = iota
+	ShardStateWaitingInQueue
+	ShardStateIdentifying
+	ShardStateResuming
+	ShardStateConnected
+)
+
+func (s ShardState) String() string {
+	switch s {
+	case ShardStateDisconnected:
+		return "disconnected"
+	case ShardStateWaitingInQueue:
+		return "waiting-in-queue"
+	case ShardStateIdentifying:
+		return "identifying"
+	case ShardStateResuming:
+		return "resuming"
+	case ShardStateConnected:
+		return "connected"
+	default:
+		return "unknown"
+	}
+}
+
+// ShardStatus is a snapshot of a shard's connection state.
+type ShardStatus struct {
+	ShardID          uint
+	State            ShardState
+	LastHeartbeatAck time.Time
+	HeartbeatLatency time.Duration
+	SequenceNumber   uint32
+
+	// GuildCount is the number of guilds handled by the shard. This is not known
+	// by the gateway, so it is populated by the disgord client.
+	GuildCount uint
+}
+
+// Status returns the current connection state of the shard.
+func (c *EvtClient) Status() ShardStatus {
+	c.RLock()
+	lastAck := c.lastHeartbeatAck
+	latency := c.heartbeatLatency
+	c.RUnlock()
+
+	return ShardStatus{
+		ShardID:          c.ShardID,
+		State:            ShardState(c.state.Load()),
+		LastHeartbeatAck: lastAck,
+		HeartbeatLatency: latency,
+		SequenceNumber:   c.sequenceNumber.Load(),
+	}
+}
+
+func (c *EvtClient) setState(state ShardState) {
+	c.state.Store(uint32(state))
+}
+
+// emitLifecycleEvent dispatches an event that is created by Disgord, and not by Discord, such that
+// users can react to changes in the shard state.
+func (c *EvtClient) emitLifecycleEvent(name string, data interface{}) {
+	if c.muted.Load() {
+		return
+	}
+	emitLifecycleEvent(c.eventChan, c.SystemShutdown, c.ShardID, name, data)
+}
+
+func emitLifecycleEvent(eventChan chan<- *Event, shutdown <-chan interface{}, shardID uint, name string, data interface{}) {
+	if eventChan == nil {
+		return
+	}
+
+	raw := []byte(`{}`)
+	if data != nil {
+		var err error
+		if raw, err = json.Marshal(data); err != nil {
+			return
+		}
+	}
+
+	select {
+	case eventChan <- &Event{Name: name, Data: raw, ShardID: shardID}:
+	case <-shutdown:
+	}
+}
+
+type shardScaledPayload struct {
+	ShardCount uint   `json:"shard_count"`
+	ShardIDs   []uint `json:"shard_ids"`
+}
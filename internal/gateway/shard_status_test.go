@@ -0,0 +1,47 @@
+// +build !integration
+
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord/internal/event"
+)
+
+func TestEvtClient_onDisconnect(t *testing.T) {
+	eChan := make(chan *Event, 2)
+	c := &EvtClient{
+		client:    &client{ShardID: 2},
+		eventChan: eChan,
+	}
+
+	// a shard that never connected should not notify
+	c.setState(ShardStateWaitingInQueue)
+	c.onDisconnect()
+	if len(eChan) != 0 {
+		t.Fatal("expected no events for a shard that never opened a connection")
+	}
+
+	c.setState(ShardStateConnected)
+	c.onDisconnect()
+	c.onDisconnect() // duplicate calls must not result in duplicate events
+
+	select {
+	case evt := <-eChan:
+		if evt.Name != event.ShardDisconnected {
+			t.Errorf("expected %s, got %s", event.ShardDisconnected, evt.Name)
+		}
+		if evt.ShardID != 2 {
+			t.Errorf("expected shard id 2, got %d", evt.ShardID)
+		}
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("missing disconnect event")
+	}
+	if len(eChan) != 0 {
+		t.Error("expected only one disconnect event")
+	}
+	if state := c.Status().State; state != ShardStateDisconnected {
+		t.Errorf("expected state %s, got %s", ShardStateDisconnected, state)
+	}
+}
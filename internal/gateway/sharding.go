@@ -5,17 +5,32 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/andersfylling/disgord/internal/constant"
+	evt "github.com/andersfylling/disgord/internal/event"
 	"github.com/andersfylling/disgord/internal/gateway/cmd"
 	"github.com/andersfylling/disgord/internal/logger"
+
+	"go.uber.org/atomic"
 )
 
 const defaultShardRateLimit time.Duration = 5*time.Second + 100*time.Millisecond
 const discordErrShardScalingRequired = 4011
 
+// autoScalingTimeout is how long the new shards of an automatic scaling can take to become ready, in
+// addition to the identify rate limit of every new shard.
+const autoScalingTimeout = time.Minute
+
+// scalingRetryDelay is the delay before a failed scaling is retried, which doubles for every retry up to
+// scalingMaxRetryDelay. A shard closed with 4011 stays offline until the scaling succeeds.
+var (
+	scalingRetryDelay    = 5 * time.Second
+	scalingMaxRetryDelay = 5 * time.Minute
+)
+
 type shardID = uint
 
 type CmdPayload interface {
@@ -32,9 +47,14 @@ func ConfigureShardConfig(ctx context.Context, client GatewayBotGetter, conf *Sh
 		return errors.New("ShardCount should only be set when you use distributed bots and have set the ShardIDs field - ShardCount is an optional field")
 	}
 
-	data, err := client.GetGatewayBot(ctx)
-	if err != nil {
-		return err
+	var data *GatewayBot
+	if conf.Replay != nil {
+		data = conf.Replay.gatewayBot()
+	} else {
+		var err error
+		if data, err = client.GetGatewayBot(ctx); err != nil {
+			return err
+		}
 	}
 
 	if len(conf.ShardIDs) > 0 || conf.ShardCount > 0 {
@@ -96,6 +116,8 @@ type ShardManager interface {
 	ShardCount() uint
 	ShardIDs() (shardIDs []uint)
 	GetShard(shardID shardID) (shard *EvtClient, err error)
+	ShardStatuses() (statuses []ShardStatus)
+	Reshard(ctx context.Context, shardCount uint, shardIDs []uint) error
 	HeartbeatLatencies() (latencies map[shardID]time.Duration, err error)
 }
 
@@ -129,14 +151,15 @@ type ShardConfig struct {
 	ConnectQueue connectQueue
 
 	// DisableAutoScaling is triggered when at least one shard gets a 4011 websocket
-	// error from Discord. This causes all the shards to disconnect and new ones are created.
+	// error from Discord. This causes a new set of shards to be created, which replaces
+	// the current shards once they are ready. See ShardManager.Reshard.
 	//
 	// default value is false unless shardIDs or ShardCount is set.
 	DisableAutoScaling bool
 
 	// OnScalingRequired is triggered when Discord closes the websocket connection
-	// with a 4011 websocket error. It may run multiple times per session. You should
-	// immediately call disconnect and scale your shards, unless you know what you're doing.
+	// with a 4011 websocket error. It may run multiple times per session. The returned
+	// shard setup is used to reshard, while the current shards stays connected.
 	//
 	// This is triggered when DisableAutoScaling is true. If DisableAutoScaling is true and
 	// OnScalingRequired is nil, this is considered an user error and will panic.
@@ -162,6 +185,14 @@ type ShardConfig struct {
 
 	// URL is fetched from the gateway before initialising a connection
 	URL string
+
+	// Recorder records the gateway traffic of every shard into a single recording, which can be
+	// replayed later on. Recording must be started by calling Recorder.Start.
+	Recorder *Recorder
+
+	// Replay replaces the Discord gateway with a recording. The shard setup is derived from the recording
+	// unless ShardIDs is set. For testing and debugging only.
+	Replay *Replay
 }
 
 // ShardManagerConfig all fields, except proxy.Dialer, is required
@@ -196,17 +227,25 @@ type shardMngr struct {
 
 	sync         *shardSync
 	connectQueue connectQueue
+
+	// pending holds the new shards while resharding. They are moved
+	// into shards once every one of them has connected.
+	pending    map[shardID]*EvtClient
+	resharding atomic.Bool
+
+	// scaling is set while the shards are scaled after a 4011
+	scaling atomic.Bool
 }
 
 var _ ShardManager = (*shardMngr)(nil)
 
-func (s *shardMngr) initShards() error {
-	baseConfig := EvtConfig{ // TODO: not nicely grouped, feel free to adjust
+func (s *shardMngr) newShard(id shardID, shardCount uint) (shard *EvtClient, err error) {
+	conf := &EvtConfig{ // TODO: not nicely grouped, feel free to adjust
 		// identity
 		Browser:             s.conf.DisgordInfo,
 		Device:              s.conf.ProjectName,
 		GuildLargeThreshold: 0, // let's not sometimes load partial guilds info. Either load everything or nothing.
-		ShardCount:          s.conf.ShardCount,
+		ShardCount:          shardCount,
 		Presence:            s.conf.DefaultBotPresence,
 
 		// lib specific
@@ -232,43 +271,27 @@ func (s *shardMngr) initShards() error {
 			if code != discordErrShardScalingRequired {
 				return
 			}
-			s.mu.Lock()
-			defer s.mu.Unlock()
-			s.conf.Logger.Info("scaling")
-
-			if !s.conf.DisableAutoScaling {
-				s.scale(code, reason)
-			} else {
-				if s.conf.OnScalingRequired == nil {
-					panic("ShardConfig.OnScalingRequired must be set")
-				}
-				var newShards []uint
-				s.conf.ShardCount, newShards = s.conf.OnScalingRequired(s.ShardIDs())
-				s.conf.ShardIDs = append(s.conf.ShardIDs, newShards...)
-
-				_ = s.Disconnect()
-				if err := s.initShards(); err != nil {
-					s.conf.Logger.Error("scaling", "init-shards", err)
-					return
-				}
-				s.conf.Logger.Info("scaling", "connecting shards")
-				if err := s.Connect(); err != nil {
-					s.conf.Logger.Error("scaling", "connect", err)
-				}
-				s.conf.Logger.Info("scaling", "connected")
-			}
+			s.onScalingRequired(id, shard, reason)
 		},
-		conn: s.conf.conn,
+		Recorder: s.conf.Recorder,
+		conn:     s.conf.conn,
+	}
+	if s.conf.Replay != nil {
+		conf.conn = s.conf.Replay.Conn(id)
 	}
 
+	shard, err = NewEventClient(id, conf)
+	return shard, err
+}
+
+func (s *shardMngr) initShards() error {
 	for _, id := range s.conf.ShardIDs {
 		if shard, alreadyConfigured := s.shards[id]; alreadyConfigured {
 			shard.evtConf.ShardCount = s.conf.ShardCount
 			continue
 		}
 
-		uniqueConfig := baseConfig // create copy, review requirement
-		shard, err := NewEventClient(id, &uniqueConfig)
+		shard, err := s.newShard(id, s.conf.ShardCount)
 		if err != nil {
 			return err
 		}
@@ -315,6 +338,9 @@ func (s *shardMngr) Disconnect() error {
 
 		shard.haveConnectedOnce.Store(false)
 	}
+	for _, shard := range s.pending {
+		_ = shard.Disconnect()
+	}
 	return nil
 }
 
@@ -333,6 +359,12 @@ func (s *shardMngr) ShardCount() uint {
 }
 
 func (s *shardMngr) ShardIDs() (shardIDs []uint) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.shardIDs()
+}
+
+func (s *shardMngr) shardIDs() (shardIDs []uint) {
 	for id := range s.shards {
 		shardIDs = append(shardIDs, id)
 	}
@@ -353,13 +385,16 @@ func (s *shardMngr) Emit(cmd string, payload CmdPayload) (guildIDs []Snowflake,
 	case *RequestGuildMembersPayload:
 		if len(s.shards) == 1 {
 			for _, shard := range s.shards {
-				return t.GuildIDs, shard.Emit(cmd, payload)
+				if err = shard.Emit(cmd, payload); err != nil {
+					return t.GuildIDs, err
+				}
+				return nil, nil
 			}
 		}
 
 		requests := make(map[uint][]Snowflake)
 		for i := range t.GuildIDs {
-			shardID := GetShardForGuildID(t.GuildIDs[i], s.ShardCount())
+			shardID := GetShardForGuildID(t.GuildIDs[i], s.conf.ShardCount)
 			requests[shardID] = append(requests[shardID], t.GuildIDs[i])
 		}
 
@@ -376,7 +411,7 @@ func (s *shardMngr) Emit(cmd string, payload CmdPayload) (guildIDs []Snowflake,
 			}
 		}
 	case *UpdateVoiceStatePayload:
-		shardID := GetShardForGuildID(t.GuildID, s.ShardCount())
+		shardID := GetShardForGuildID(t.GuildID, s.conf.ShardCount)
 		if shard, ok := s.shards[shardID]; ok {
 			err = shard.Emit(cmd, payload)
 		} else {
@@ -405,6 +440,21 @@ func (s *shardMngr) GetShard(shardID shardID) (shard *EvtClient, err error) {
 	return nil, errors.New("no shard with given id " + fmt.Sprint(shardID))
 }
 
+// ShardStatuses returns the status of every local shard, sorted by shard ID.
+func (s *shardMngr) ShardStatuses() (statuses []ShardStatus) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses = make([]ShardStatus, 0, len(s.shards))
+	for _, shard := range s.shards {
+		statuses = append(statuses, shard.Status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ShardID < statuses[j].ShardID
+	})
+	return statuses
+}
+
 func (s *shardMngr) HeartbeatLatencies() (latencies map[shardID]time.Duration, err error) {
 	latencies = make(map[shardID]time.Duration)
 	for id := range s.shards {
@@ -416,47 +466,238 @@ func (s *shardMngr) HeartbeatLatencies() (latencies map[shardID]time.Duration, e
 	return
 }
 
-func (s *shardMngr) scale(code int, reason string) {
-	if s.conf.DisableAutoScaling {
-		s.conf.Logger.Debug("discord require websocket shards to scale up but auto scaling is disabled - did not handle scaling internally")
-		return
+// onScalingRequired handles Discord closing a shard with a 4011 websocket error. The shard can no
+// longer connect, while the remaining shards keeps running until a new set of shards is ready. A failed
+// scaling is retried with a backoff until the closed shard has been replaced, or the system shuts down.
+func (s *shardMngr) onScalingRequired(id shardID, closed *EvtClient, reason string) {
+	if !s.scaling.CAS(false, true) {
+		return // the ongoing scaling replaces every shard
 	}
+	defer s.scaling.Store(false)
+
+	delay := scalingRetryDelay
+	for {
+		// another reshard might have replaced the closed shard in the meantime
+		s.mu.RLock()
+		replaced := s.shards[id] != closed
+		s.mu.RUnlock()
+		if replaced {
+			return
+		}
+
+		err := s.scale(id, reason)
+		if err == nil {
+			return
+		}
+		s.conf.Logger.Error("scaling", err, "- retrying in", delay)
 
-	s.conf.Logger.Error("discord require websocket shards to scale up - starting auto scaling:", reason)
+		select {
+		case <-time.After(delay):
+		case <-s.conf.ShutdownChan:
+			return
+		}
+		if delay *= 2; delay > scalingMaxRetryDelay {
+			delay = scalingMaxRetryDelay
+		}
+	}
+}
 
-	unchandledGuilds := s.redistributeMsgs(func() {
+// scale creates a new set of shards with the shard count required by Discord
+func (s *shardMngr) scale(id shardID, reason string) error {
+	s.mu.RLock()
+	autoScaling := !s.conf.DisableAutoScaling
+	currentShardIDs := append([]uint(nil), s.conf.ShardIDs...)
+	s.mu.RUnlock()
+
+	var shardCount uint
+	var shardIDs []uint
+	if autoScaling {
+		s.conf.Logger.Error("discord require websocket shards to scale up - starting auto scaling:", reason)
 		data, err := s.conf.RESTClient.GetGatewayBot(context.Background())
 		if err != nil {
-			s.conf.Logger.Error("autoscaling", err)
-			return
+			return err
+		}
+		shardCount = data.Shards
+	} else {
+		if s.conf.OnScalingRequired == nil {
+			panic("ShardConfig.OnScalingRequired must be set")
+		}
+		var additionalShardIDs []uint
+		shardCount, additionalShardIDs = s.conf.OnScalingRequired(currentShardIDs)
+		shardIDs = append(currentShardIDs, additionalShardIDs...)
+	}
+
+	s.conf.Logger.Info("scaling", "shard", id, "requires", shardCount, "shards")
+
+	// the new shards are discarded if they do not become ready in time
+	nrOfNewShards := uint(len(shardIDs))
+	if nrOfNewShards == 0 {
+		nrOfNewShards = shardCount
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), autoScalingTimeout+time.Duration(nrOfNewShards)*defaultShardRateLimit)
+	defer cancel()
+	return s.Reshard(ctx, shardCount, shardIDs)
+}
+
+// Reshard creates a new set of shards alongside the current ones. Once every new shard has received
+// a Ready event, the new shards take over the event dispatching and the old shards are disconnected.
+// This allows for restarting or scaling the shards without the bot going offline. Events are not
+// dispatched from the new shards before the hand over.
+//
+// If shardIDs is empty, this instance is expected to handle every shard, [0, shardCount).
+func (s *shardMngr) Reshard(ctx context.Context, shardCount uint, shardIDs []uint) (err error) {
+	if shardCount == 0 {
+		return errors.New("shard count must be larger than 0")
+	}
+	if !s.resharding.CAS(false, true) {
+		return errors.New("resharding is already in progress")
+	}
+	defer s.resharding.Store(false)
+
+	if len(shardIDs) == 0 {
+		for i := uint(0); i < shardCount; i++ {
+			shardIDs = append(shardIDs, i)
+		}
+	}
+	for _, id := range shardIDs {
+		if id >= shardCount {
+			return fmt.Errorf("shard id %d is out of range, there are only %d shards", id, shardCount)
+		}
+	}
+
+	s.mu.Lock()
+	s.pending = make(map[shardID]*EvtClient)
+	for _, id := range shardIDs {
+		shard, err := s.newShard(id, shardCount)
+		if err != nil {
+			s.pending = nil
+			s.mu.Unlock()
+			return err
 		}
+		shard.muted.Store(true) // until hand over
+		s.pending[id] = shard
+	}
+	pending := s.pending
+	s.mu.Unlock()
+
+	discard := func() {
+		s.mu.Lock()
+		s.pending = nil
+		s.mu.Unlock()
+		for _, shard := range pending {
+			_ = shard.Disconnect()
+		}
+	}
 
-		_ = s.Disconnect()
+	s.conf.Logger.Info("resharding", "connecting", len(pending), "new shards")
+	for _, shard := range pending {
+		if err = shard.Connect(); err != nil {
+			discard()
+			return err
+		}
+	}
 
-		s.conf.URL = data.URL
-		for i := uint(len(s.conf.ShardIDs) - 1); i < data.Shards; i++ {
-			s.conf.ShardIDs = append(s.conf.ShardIDs, i)
-			s.conf.ShardCount++
+	// wait for every new shard to receive a Ready event
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		ready := true
+		for _, shard := range pending {
+			if shard.Status().State != ShardStateConnected {
+				ready = false
+				break
+			}
 		}
-		if err := s.initShards(); err != nil {
-			s.conf.Logger.Error("autoscaling", "init-shards", err)
-			return
+		if ready {
+			break
 		}
-		if err := s.Connect(); err != nil {
-			s.conf.Logger.Error("autoscaling", "connect", err)
+
+		select {
+		case <-ctx.Done():
+			discard()
+			return ctx.Err()
+		case <-s.conf.ShutdownChan:
+			discard()
+			return errors.New("system is shutting down")
+		case <-ticker.C:
+		}
+	}
+
+	// hand over
+	var old map[shardID]*EvtClient
+	unhandledGuildIDs := s.redistributeMsgs(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		old = s.shards
+		s.shards = pending
+		s.pending = nil
+		s.conf.ShardCount = shardCount
+		s.conf.ShardIDs = shardIDs
+
+		for _, shard := range old {
+			shard.muted.Store(true)
+		}
+		for _, shard := range pending {
+			shard.muted.Store(false)
 		}
 	})
+	for _, shard := range old {
+		_ = shard.Disconnect()
+	}
+	s.conf.Logger.Info("resharding", "new shards took over, total number of shards is now", shardCount)
+
+	if len(unhandledGuildIDs) > 0 && s.conf.OnScalingDiscardedRequests != nil {
+		s.conf.OnScalingDiscardedRequests(unhandledGuildIDs)
+	}
+
+	s.mu.RLock()
+	s.emitScaled()
+	s.mu.RUnlock()
+	return nil
+}
+
+// emitScaled notifies the user about the new shard setup. Caller must hold the lock.
+func (s *shardMngr) emitScaled() {
+	shardIDs := s.shardIDs()
+	sort.Slice(shardIDs, func(i, j int) bool {
+		return shardIDs[i] < shardIDs[j]
+	})
 
-	if s.conf.OnScalingDiscardedRequests != nil {
-		s.conf.OnScalingDiscardedRequests(unchandledGuilds)
+	var firstShardID uint
+	if len(shardIDs) > 0 {
+		firstShardID = shardIDs[0]
 	}
+	go emitLifecycleEvent(s.conf.EventChan, s.conf.ShutdownChan, firstShardID, evt.ShardScaled, &shardScaledPayload{
+		ShardCount: s.conf.ShardCount,
+		ShardIDs:   shardIDs,
+	})
+}
+
+// mergeableMemberRequests reports whether the requests only differ by guild IDs. Requests with different
+// nonces are never merged, as the chunks of the second request would be sent with the nonce of the first.
+func mergeableMemberRequests(a, b *RequestGuildMembersPayload) bool {
+	if a.Nonce != b.Nonce || a.Query != b.Query || a.Limit != b.Limit || a.Presences != b.Presences {
+		return false
+	}
+	if len(a.UserIDs) != len(b.UserIDs) {
+		return false
+	}
+	for i := range a.UserIDs {
+		if a.UserIDs[i] != b.UserIDs[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func (s *shardMngr) redistributeMsgs(scaleShards func()) (unhandledGuildIDs []Snowflake) {
 	var messages []*clientPacket
+	s.mu.RLock()
 	for _, shard := range s.shards {
 		messages = append(messages, shard.messageQueue.Steal()...)
 	}
+	s.mu.RUnlock()
 
 	scaleShards()
 
@@ -483,6 +724,9 @@ func (s *shardMngr) redistributeMsgs(scaleShards func()) (unhandledGuildIDs []Sn
 			if rgm2, ok = m2.Data.(*RequestGuildMembersPayload); !ok {
 				continue
 			}
+			if !mergeableMemberRequests(rgm1, rgm2) {
+				continue
+			}
 			rgm1.GuildIDs = append(rgm1.GuildIDs, rgm2.GuildIDs...)
 			messages[j] = nil
 		}
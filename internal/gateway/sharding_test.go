@@ -4,6 +4,7 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -148,6 +149,120 @@ func TestRedistributeShardMessages(t *testing.T) {
 	verifyDistribution("3")
 }
 
+func TestRedistributeShardMessages_nonces(t *testing.T) {
+	config := ShardManagerConfig{
+		BotToken:     "test",
+		ShutdownChan: make(chan interface{}),
+		EventChan:    make(chan *Event),
+		Logger:       &logger.Empty{},
+		ShardConfig: ShardConfig{
+			URL:        "localhost:6060",
+			ShardIDs:   []uint{0},
+			ShardCount: 1,
+		},
+	}
+	defer func() {
+		close(config.ShutdownChan)
+		close(config.EventChan)
+	}()
+
+	mngr := NewShardMngr(config)
+	if err := mngr.initShards(); err != nil {
+		t.Fatal(err)
+	}
+	mngr.shards[0].haveConnectedOnce.Store(true)
+
+	payloads := []*RequestGuildMembersPayload{
+		{GuildIDs: []Snowflake{1}, Nonce: "first"},
+		{GuildIDs: []Snowflake{2}, Nonce: "second"},
+		{GuildIDs: []Snowflake{3}, Nonce: "first"},
+		{GuildIDs: []Snowflake{4}, Nonce: "second", Query: "a"},
+	}
+	for _, p := range payloads {
+		if _, err := mngr.Emit(cmd.RequestGuildMembers, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mngr.redistributeMsgs(func() {})
+
+	requests := make(map[string][][]Snowflake)
+	for _, m := range mngr.shards[0].messageQueue.messages {
+		p := m.Data.(*RequestGuildMembersPayload)
+		requests[p.Nonce+p.Query] = append(requests[p.Nonce+p.Query], p.GuildIDs)
+	}
+	if len(requests["first"]) != 1 || len(requests["first"][0]) != 2 {
+		t.Errorf("expected the requests with the same nonce to be merged, got %v", requests["first"])
+	}
+	if len(requests["second"]) != 1 || len(requests["second"][0]) != 1 || requests["second"][0][0] != 2 {
+		t.Errorf("expected the requests with another nonce to be kept, got %v", requests["second"])
+	}
+	if len(requests["seconda"]) != 1 {
+		t.Errorf("expected the requests with another query to be kept, got %v", requests["seconda"])
+	}
+}
+
+func TestShardMngr_onScalingRequired(t *testing.T) {
+	defer func(delay, max time.Duration) {
+		scalingRetryDelay, scalingMaxRetryDelay = delay, max
+	}(scalingRetryDelay, scalingMaxRetryDelay)
+	scalingRetryDelay, scalingMaxRetryDelay = time.Millisecond, 2*time.Millisecond
+
+	attempts := make(chan struct{}, 10)
+	config := ShardManagerConfig{
+		BotToken:     "test",
+		ShutdownChan: make(chan interface{}),
+		EventChan:    make(chan *Event),
+		Logger:       &logger.Empty{},
+		RESTClient: &GatewayBotGetterMock{
+			get: func() (gateway *GatewayBot, err error) {
+				attempts <- struct{}{}
+				return nil, errors.New("unavailable")
+			},
+		},
+		ShardConfig: ShardConfig{
+			URL:        "localhost:6060",
+			ShardIDs:   []uint{0},
+			ShardCount: 1,
+		},
+	}
+	defer close(config.EventChan)
+
+	mngr := NewShardMngr(config)
+	if err := mngr.initShards(); err != nil {
+		t.Fatal(err)
+	}
+	closed := mngr.shards[0]
+
+	done := make(chan struct{})
+	go func() {
+		mngr.onScalingRequired(0, closed, "test")
+		close(done)
+	}()
+
+	// a failed scaling is retried, while another 4011 does not start a second scaling
+	for i := 0; i < 3; i++ {
+		select {
+		case <-attempts:
+		case <-time.After(time.Second):
+			t.Fatal("expected the scaling to be retried")
+		}
+		if i == 0 {
+			mngr.onScalingRequired(0, closed, "test")
+		}
+	}
+
+	// the retries end once the shard has been replaced
+	mngr.mu.Lock()
+	mngr.shards[0] = &EvtClient{}
+	mngr.mu.Unlock()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the scaling to stop once the shard was replaced")
+	}
+	close(config.ShutdownChan)
+}
+
 func TestIdentifyRateLimiting(t *testing.T) {
 	u := "localhost:6060"
 	mock := &GatewayBotGetterMock{
@@ -219,3 +334,101 @@ func TestIdentifyRateLimiting(t *testing.T) {
 		}
 	}
 }
+
+func TestReshard(t *testing.T) {
+	u := "localhost:6060"
+	mock := &GatewayBotGetterMock{
+		get: func() (gateway *GatewayBot, err error) {
+			return &GatewayBot{
+				Shards:  2,
+				Gateway: Gateway{u},
+			}, nil
+		},
+	}
+	config := ShardManagerConfig{
+		BotToken:     "test",
+		ShutdownChan: make(chan interface{}),
+		EventChan:    make(chan *Event, 10),
+		Logger:       &logger.Empty{},
+		ShardConfig: ShardConfig{
+			// the shards never connects, the test decides when they are ready
+			ConnectQueue: func(shardID uint, cb func() error) error {
+				return nil
+			},
+		},
+	}
+	defer close(config.ShutdownChan)
+
+	if err := ConfigureShardConfig(context.Background(), mock, &config.ShardConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	mngr := NewShardMngr(config)
+	if err := mngr.initShards(); err != nil {
+		t.Fatal(err)
+	}
+	old := mngr.shards
+
+	if err := mngr.Reshard(context.Background(), 2, []uint{0, 2}); err == nil {
+		t.Error("expected an error for a shard id that is out of range")
+	}
+
+	// a cancelled context must keep the current shards
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := mngr.Reshard(ctx, 4, nil); err == nil {
+		t.Fatal("expected an error when the context is cancelled")
+	}
+	if len(mngr.shards) != 2 || mngr.pending != nil {
+		t.Fatal("the current shards should not change when resharding is aborted")
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- mngr.Reshard(context.Background(), 4, nil)
+	}()
+
+	// let the new shards connect
+	var pending map[shardID]*EvtClient
+	for pending == nil {
+		<-time.After(10 * time.Millisecond)
+		mngr.mu.RLock()
+		pending = mngr.pending
+		mngr.mu.RUnlock()
+	}
+	if len(mngr.ShardIDs()) != 2 {
+		t.Error("the old shards should be kept until the new shards are ready")
+	}
+	for _, shard := range pending {
+		if !shard.muted.Load() {
+			t.Error("new shards must be muted until they take over")
+		}
+		shard.setState(ShardStateConnected)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("resharding did not finish")
+	}
+
+	if mngr.ShardCount() != 4 || len(mngr.ShardIDs()) != 4 {
+		t.Errorf("expected 4 shards, got %d (%d local)", mngr.ShardCount(), len(mngr.ShardIDs()))
+	}
+	for _, shard := range mngr.shards {
+		if shard.muted.Load() {
+			t.Error("new shards should dispatch events after hand over")
+		}
+		if shard.evtConf.ShardCount != 4 {
+			t.Error("new shards must identify with the new shard count")
+		}
+	}
+	for _, shard := range old {
+		if !shard.muted.Load() {
+			t.Error("old shards should be muted after hand over")
+		}
+	}
+}
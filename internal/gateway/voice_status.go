@@ -0,0 +1,68 @@
+package gateway
+
+// VoiceConnectionState describes what a voice client is currently doing with its websocket connection.
+type VoiceConnectionState uint32
+
+const (
+	// VoiceConnectionStateDisconnected is used when the websocket connection was lost, and a reconnect
+	// is pending.
+	VoiceConnectionStateDisconnected VoiceConnectionState = iota
+	VoiceConnectionStateIdentifying
+	VoiceConnectionStateResuming
+	VoiceConnectionStateConnected
+
+	// VoiceConnectionStateClosed is used once the connection was closed on purpose, or Discord closed it
+	// in a way that can not be recovered from. The voice client will not reconnect.
+	VoiceConnectionStateClosed
+)
+
+func (s VoiceConnectionState) String() string {
+	switch s {
+	case VoiceConnectionStateDisconnected:
+		return "disconnected"
+	case VoiceConnectionStateIdentifying:
+		return "identifying"
+	case VoiceConnectionStateResuming:
+		return "resuming"
+	case VoiceConnectionStateConnected:
+		return "connected"
+	case VoiceConnectionStateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// State returns the current state of the voice websocket connection.
+func (c *VoiceClient) State() VoiceConnectionState {
+	return VoiceConnectionState(c.state.Load())
+}
+
+func (c *VoiceClient) setState(state VoiceConnectionState) {
+	for {
+		previous := c.state.Load()
+		if previous == uint32(state) || previous == uint32(VoiceConnectionStateClosed) {
+			// a closed voice client stays closed
+			return
+		}
+		if c.state.CAS(previous, uint32(state)) {
+			break
+		}
+	}
+
+	if state == VoiceConnectionStateClosed {
+		close(c.active)
+	}
+	if c.conf.OnStateChange != nil {
+		c.conf.OnStateChange(state)
+	}
+}
+
+// onDisconnect is called every time the websocket connection is closed, regardless of the reason.
+func (c *VoiceClient) onDisconnect() {
+	if c.requestedDisconnect.Load() {
+		c.setState(VoiceConnectionStateClosed)
+	} else {
+		c.setState(VoiceConnectionStateDisconnected)
+	}
+}
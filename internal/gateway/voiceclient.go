@@ -42,6 +42,21 @@ type VoiceConfig struct {
 	Logger logger.Logger
 
 	SystemShutdown chan interface{}
+
+	// OnSpeaking and OnClientDisconnect are called when the voice state of another user changes.
+	OnSpeaking         func(evt *VoiceSpeaking)
+	OnClientDisconnect func(evt *VoiceClientDisconnect)
+
+	// OnStateChange is called whenever the websocket connection is lost, resumed, identified again or closed.
+	OnStateChange func(state VoiceConnectionState)
+
+	// OnReconnect is called when a reconnect could not be resumed, and Discord sent a new ready payload. The
+	// UDP connection must then be established again. The voice client is not considered connected until
+	// OnReconnect returns, and a returned error causes another reconnect.
+	OnReconnect func(ready *VoiceReady) error
+
+	// for testing only
+	conn Conn
 }
 
 func (conf *VoiceConfig) validate() {
@@ -56,20 +71,25 @@ type VoiceClient struct {
 
 	haveIdentifiedOnce atomic.Bool
 
-	active         chan interface{}
-	SystemShutdown chan interface{}
+	// state is a VoiceConnectionState
+	state atomic.Uint32
+
+	// active is closed once the voice client is closed, and will no longer reconnect
+	active chan interface{}
 }
 
 func NewVoiceClient(conf *VoiceConfig) (client *VoiceClient, err error) {
 	conf.validate()
 
 	client = &VoiceClient{
-		conf: conf,
+		conf:   conf,
+		active: make(chan interface{}),
 	}
 	client.client, err = newClient(0, &config{
 		Logger:     conf.Logger,
 		Endpoint:   conf.Endpoint,
 		HTTPClient: conf.HTTPClient,
+		conn:       conf.conn,
 		DiscordPktPool: &sync.Pool{
 			New: func() interface{} {
 				return &DiscordPacket{}
@@ -77,6 +97,8 @@ func NewVoiceClient(conf *VoiceConfig) (client *VoiceClient, err error) {
 		},
 		messageQueueLimit: conf.MessageQueueLimit,
 		SystemShutdown:    conf.SystemShutdown,
+		onDisconnect:      client.onDisconnect,
+		stopReconnecting:  client.active,
 	}, client.internalConnect)
 	if err != nil {
 		return nil, err
@@ -93,8 +115,9 @@ func NewVoiceClient(conf *VoiceConfig) (client *VoiceClient, err error) {
 //
 //////////////////////////////////////////////////////
 
+// Active returns a channel that is closed once the voice client is closed and will no longer reconnect.
 func (c *VoiceClient) Active() <-chan interface{} {
-	return c.SystemShutdown
+	return c.active
 }
 
 func (c *VoiceClient) setupBehaviors() {
@@ -113,8 +136,8 @@ func (c *VoiceClient) setupBehaviors() {
 			opcode.VoiceHeartbeatAck:       c.onHeartbeatAck,
 			opcode.VoiceHello:              c.onHello,
 			opcode.VoiceSessionDescription: c.onVoiceSessionDescription,
-			opcode.VoiceSpeaking:           nop,
-			opcode.VoiceClientDisconnect:   nop,
+			opcode.VoiceSpeaking:           c.onSpeaking,
+			opcode.VoiceClientDisconnect:   c.onClientDisconnect,
 
 			// undocumented operations
 			opcode.OpCode(10): nop,
@@ -212,16 +235,10 @@ func (c *VoiceClient) onHello(v interface{}) (err error) {
 	if err = json.Unmarshal(p.Data, helloPk); err != nil {
 		return err
 	}
-	interval := uint(helloPk.HeartbeatInterval)
+	// a hello is sent for every new websocket connection, and the interval might differ when the
+	// voice server has changed
 	c.Lock()
-	if interval == c.heartbeatInterval {
-		c.Unlock()
-		return nil
-	} else if c.heartbeatInterval > 0 {
-		c.Unlock()
-		return errors.New("a new hello packet was sent, with a different interval - please make a github issue at https://github.com/andersfylling/disgord")
-	}
-	c.heartbeatInterval = interval
+	c.heartbeatInterval = uint(helloPk.HeartbeatInterval)
 	c.Unlock()
 
 	c.sendVoiceHelloPacket()
@@ -243,6 +260,34 @@ func (c *VoiceClient) onVoiceSessionDescription(v interface{}) (err error) {
 	return nil
 }
 
+func (c *VoiceClient) onSpeaking(v interface{}) (err error) {
+	p := v.(*DiscordPacket)
+
+	speakingPk := &VoiceSpeaking{}
+	if err = json.Unmarshal(p.Data, speakingPk); err != nil {
+		return err
+	}
+
+	if c.conf.OnSpeaking != nil {
+		c.conf.OnSpeaking(speakingPk)
+	}
+	return nil
+}
+
+func (c *VoiceClient) onClientDisconnect(v interface{}) (err error) {
+	p := v.(*DiscordPacket)
+
+	disconnectPk := &VoiceClientDisconnect{}
+	if err = json.Unmarshal(p.Data, disconnectPk); err != nil {
+		return err
+	}
+
+	if c.conf.OnClientDisconnect != nil {
+		c.conf.OnClientDisconnect(disconnectPk)
+	}
+	return nil
+}
+
 //////////////////////////////////////////////////////
 //
 // BEHAVIOR: heartbeat
@@ -281,6 +326,9 @@ func (c *VoiceClient) internalConnect() (evt interface{}, err error) {
 	if c.conf.Endpoint == "" {
 		panic("missing websocket endpoint. Must be set before constructing the sockets")
 	}
+	if c.State() == VoiceConnectionStateClosed {
+		return nil, errors.New("voice client is closed")
+	}
 
 	waitingChan := make(chan interface{}, 2)
 	c.onceChannels.Add(opcode.VoiceReady, waitingChan)
@@ -291,8 +339,12 @@ func (c *VoiceClient) internalConnect() (evt interface{}, err error) {
 		close(waitingChan)
 	}()
 
+	c.RLock()
+	endpoint := c.conf.Endpoint
+	c.RUnlock()
+
 	// establish ws connection
-	if err := c.conn.Open(context.Background(), c.conf.Endpoint, nil); err != nil {
+	if err := c.conn.Open(context.Background(), endpoint, nil); err != nil {
 		return nil, err
 	}
 
@@ -302,10 +354,8 @@ func (c *VoiceClient) internalConnect() (evt interface{}, err error) {
 	// we can now interact with Discord
 	c.haveConnectedOnce.Store(true)
 	c.isConnected.Store(true)
-	go c.receiver(ctx)
-	go c.emitter(ctx)
+	c.startGoroutines(ctx)
 	go c.startBehaviors(ctx)
-	go c.prepareHeartbeating(ctx)
 	go func() {
 		select {
 		case <-ctx.Done():
@@ -332,6 +382,16 @@ func (c *VoiceClient) internalConnect() (evt interface{}, err error) {
 
 	select {
 	case evt = <-waitingChan:
+		// the first ready is handled by the caller of Connect, while a new ready on reconnects means
+		// the session could not be resumed
+		if ready, ok := evt.(*VoiceReady); ok && c.isReconnecting.Load() && c.conf.OnReconnect != nil {
+			if err = c.conf.OnReconnect(ready); err != nil {
+				c.haveIdentifiedOnce.Store(false)
+				_ = c.disconnect()
+				return nil, err
+			}
+		}
+		c.setState(VoiceConnectionStateConnected)
 		c.log.Info(c.getLogPrefix(), "connected")
 	case <-ctx.Done():
 		c.isConnected.Store(false)
@@ -343,6 +403,25 @@ func (c *VoiceClient) internalConnect() (evt interface{}, err error) {
 	return evt, err
 }
 
+// Reconnect closes the websocket connection and connects to the given voice server. Discord requires this
+// when the voice server of the guild changes, for example after a region change or a server failover. A
+// session can not be resumed on another voice server, so the new connection is identified and
+// VoiceConfig.OnReconnect is called with the new ready payload.
+func (c *VoiceClient) Reconnect(endpoint, token string) {
+	c.Lock()
+	c.conf.Endpoint = endpoint
+	c.conf.Token = token
+	c.Unlock()
+	c.haveIdentifiedOnce.Store(false)
+
+	// a reconnect that is already in progress uses the new endpoint on its next attempt
+	go func() {
+		if err := c.reconnect(); err != nil {
+			c.log.Error(c.getLogPrefix(), "reconnecting to a new voice server failed: ", err.Error())
+		}
+	}()
+}
+
 // Emit for voice client needs to bypass the normal Emit restrictions.
 // TODO: put more of the code flow of disgord/voiceclient.go into the websocket pkg.
 func (c *VoiceClient) Emit(name string, data interface{}) error {
@@ -352,28 +431,34 @@ func (c *VoiceClient) Emit(name string, data interface{}) error {
 func (c *VoiceClient) sendVoiceHelloPacket() {
 	// if this is a new connection we can drop the resume packet
 	if !c.haveIdentifiedOnce.Load() {
+		c.setState(VoiceConnectionStateIdentifying)
 		if err := sendVoiceIdentityPacket(c); err != nil {
 			c.log.Error(c.getLogPrefix(), err)
 		}
 		return
 	}
 
-	resumeData := struct {
-		GuildID   Snowflake `json:"server_id"`
-		SessionID string    `json:"session_id"`
-		Token     string    `json:"token"`
-	}{c.conf.GuildID, c.conf.SessionID, c.conf.Token}
-	_ = c.emit(cmd.VoiceResume, &resumeData)
+	c.RLock()
+	resumeData := voiceResume{c.conf.GuildID, c.conf.SessionID, c.conf.Token}
+	c.RUnlock()
+
+	c.setState(VoiceConnectionStateResuming)
+	if err := c.emit(cmd.VoiceResume, &resumeData); err != nil {
+		c.log.Error(c.getLogPrefix(), err)
+	}
 }
 
 func sendVoiceIdentityPacket(m *VoiceClient) (err error) {
 	// https://discord.com/developers/docs/topics/gateway#identify
-	err = m.emit(cmd.VoiceIdentify, &voiceIdentify{
+	m.RLock()
+	identity := &voiceIdentify{
 		GuildID:   m.conf.GuildID,
 		UserID:    m.conf.UserID,
 		SessionID: m.conf.SessionID,
 		Token:     m.conf.Token,
-	})
+	}
+	m.RUnlock()
+	err = m.emit(cmd.VoiceIdentify, identity)
 
 	m.haveIdentifiedOnce.Store(true)
 	return
@@ -0,0 +1,208 @@
+// +build !integration
+
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/andersfylling/disgord/internal/gateway/opcode"
+	"github.com/andersfylling/disgord/internal/logger"
+)
+
+// voiceTestConn is a websocket connection where the test acts as the voice server.
+type voiceTestConn struct {
+	connected atomic.Bool
+	opened    chan string
+	written   chan *clientPacket
+	reading   chan []byte
+	closing   chan error
+}
+
+func (c *voiceTestConn) Open(_ context.Context, endpoint string, _ http.Header) error {
+	c.connected.Store(true)
+	c.opened <- endpoint
+	return nil
+}
+
+func (c *voiceTestConn) Close() error {
+	c.connected.Store(false)
+	return nil
+}
+
+func (c *voiceTestConn) WriteJSON(v interface{}) error {
+	c.written <- v.(*clientPacket)
+	return nil
+}
+
+func (c *voiceTestConn) Read(ctx context.Context) ([]byte, error) {
+	select {
+	case packet := <-c.reading:
+		return packet, nil
+	case err := <-c.closing:
+		c.connected.Store(false)
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *voiceTestConn) Disconnected() bool {
+	return !c.connected.Load()
+}
+
+var _ Conn = (*voiceTestConn)(nil)
+
+func TestVoiceClient_reconnect(t *testing.T) {
+	conn := &voiceTestConn{
+		opened:  make(chan string, 1),
+		written: make(chan *clientPacket, 100),
+		reading: make(chan []byte),
+		closing: make(chan error),
+	}
+	states := make(chan VoiceConnectionState, 20)
+	reconnects := make(chan *VoiceReady, 1)
+	shutdown := make(chan interface{})
+	defer close(shutdown)
+
+	client, err := NewVoiceClient(&VoiceConfig{
+		GuildID:        1,
+		UserID:         2,
+		SessionID:      "session",
+		Token:          "first",
+		Endpoint:       "wss://first",
+		Logger:         &logger.Empty{},
+		SystemShutdown: shutdown,
+		OnStateChange: func(state VoiceConnectionState) {
+			states <- state
+		},
+		OnReconnect: func(ready *VoiceReady) error {
+			reconnects <- ready
+			return nil
+		},
+		conn: conn,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectOpen := func(t *testing.T, endpoint string) {
+		t.Helper()
+		select {
+		case got := <-conn.opened:
+			if got != endpoint {
+				t.Fatalf("expected a connection to %s, got %s", endpoint, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a websocket connection")
+		}
+		conn.reading <- []byte(`{"op":8,"d":{"heartbeat_interval":41250}}`)
+	}
+	expectWrite := func(t *testing.T, op opcode.OpCode) *clientPacket {
+		t.Helper()
+		timeout := time.After(time.Second)
+		for {
+			select {
+			case p := <-conn.written:
+				if p.Op == opcode.VoiceHeartbeat {
+					continue
+				}
+				if p.Op != op {
+					t.Fatalf("expected op %d, got %d", op, p.Op)
+				}
+				return p
+			case <-timeout:
+				t.Fatalf("timed out waiting for op %d", op)
+			}
+		}
+	}
+	expectStates := func(t *testing.T, expected ...VoiceConnectionState) {
+		t.Helper()
+		for _, state := range expected {
+			select {
+			case got := <-states:
+				if got != state {
+					t.Fatalf("expected state %s, got %s", state, got)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for state %s", state)
+			}
+		}
+	}
+
+	connected := make(chan error)
+	go func() {
+		_, err := client.Connect()
+		connected <- err
+	}()
+	expectOpen(t, "wss://first")
+	expectWrite(t, opcode.VoiceIdentify)
+	conn.reading <- []byte(`{"op":2,"d":{"ssrc":10,"ip":"127.0.0.1","port":1234,"modes":[]}}`)
+	if err = <-connected; err != nil {
+		t.Fatal(err)
+	}
+	expectStates(t, VoiceConnectionStateIdentifying, VoiceConnectionStateConnected)
+
+	t.Run("resume", func(t *testing.T) {
+		conn.closing <- &CloseErr{code: 4015, info: "voice server crashed"}
+		expectOpen(t, "wss://first")
+		resume := expectWrite(t, opcode.VoiceResume).Data.(*voiceResume)
+		if resume.SessionID != "session" || resume.Token != "first" {
+			t.Errorf("unexpected resume payload %+v", resume)
+		}
+		conn.reading <- []byte(`{"op":9,"d":null}`)
+		expectStates(t, VoiceConnectionStateDisconnected, VoiceConnectionStateResuming, VoiceConnectionStateConnected)
+	})
+
+	t.Run("session no longer valid", func(t *testing.T) {
+		conn.closing <- &CloseErr{code: 4006, info: "session no longer valid"}
+		expectOpen(t, "wss://first")
+		expectWrite(t, opcode.VoiceIdentify)
+		conn.reading <- []byte(`{"op":2,"d":{"ssrc":15,"ip":"127.0.0.1","port":1234,"modes":[]}}`)
+		select {
+		case ready := <-reconnects:
+			if ready.SSRC != 15 {
+				t.Errorf("expected ssrc 15, got %d", ready.SSRC)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("OnReconnect was not called")
+		}
+		expectStates(t, VoiceConnectionStateDisconnected, VoiceConnectionStateIdentifying, VoiceConnectionStateConnected)
+	})
+
+	t.Run("new voice server", func(t *testing.T) {
+		client.Reconnect("wss://second", "second")
+		expectOpen(t, "wss://second")
+		identity := expectWrite(t, opcode.VoiceIdentify).Data.(*voiceIdentify)
+		if identity.Token != "second" {
+			t.Errorf("expected the new token, got %s", identity.Token)
+		}
+		conn.reading <- []byte(`{"op":2,"d":{"ssrc":20,"ip":"127.0.0.1","port":1234,"modes":[]}}`)
+		select {
+		case ready := <-reconnects:
+			if ready.SSRC != 20 {
+				t.Errorf("expected ssrc 20, got %d", ready.SSRC)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("OnReconnect was not called")
+		}
+		expectStates(t, VoiceConnectionStateDisconnected, VoiceConnectionStateIdentifying, VoiceConnectionStateConnected)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		_ = client.Disconnect()
+		expectStates(t, VoiceConnectionStateClosed)
+		select {
+		case <-client.Active():
+		default:
+			t.Error("expected the active channel to be closed")
+		}
+		if _, err := client.internalConnect(); err == nil {
+			t.Error("a closed voice client should not connect")
+		}
+	})
+}
@@ -63,6 +63,9 @@ func (g *nhooyr) WriteJSON(v interface{}) (err error) {
 }
 
 func (g *nhooyr) Close() (err error) {
+	if g.c == nil {
+		return nil // never opened
+	}
 	err = g.c.Close(websocket.StatusNormalClosure, "Bot is shutting down")
 	if !g.isConnected.Load() {
 		err = nil // discard error if we're already closed, should be a noop anyways
@@ -47,12 +47,30 @@ func main() {
 	})
 
 	// Next up, read event/events.go to see which events are actual Discord events
-	keysFile, err := parser.ParseFile(token.NewFileSet(), "internal/event/events.go", nil, parser.ParseComments)
+	// and event/disgord.go to see which events are emitted by Disgord itself
+	readEventKeys(index, "internal/event/events.go", false)
+	readEventKeys(index, "internal/event/disgord.go", true)
+
+	for _, event := range events {
+		if event.Docs == nil {
+			fmt.Fprintf(os.Stderr, "WARNING: %s is defined in events.go, but has no docs in event/events.go or event/disgord.go!\n", event.varName)
+		}
+	}
+
+	// And finally pass the event information to different templates to generate some files
+	makeFile(events, "internal/generate/events/events.gohtml", "events_gen.go")
+	makeFile(events, "internal/generate/events/events_internal.gohtml", "internal/event/events_gen.go")
+	makeFile(events, "internal/generate/events/cache.gohtml", "cache_gen.go")
+	makeFile(events, "internal/generate/events/reactor.gotpl", "reactor_gen.go")
+}
+
+func readEventKeys(index map[string]*eventName, filename string, disgordEvents bool) {
+	keysFile, err := parser.ParseFile(token.NewFileSet(), filename, nil, parser.ParseComments)
 	if err != nil {
 		panic(err)
 	}
 
-	// Read the const key documentation from event/events.go
+	// Read the const key documentation
 	for _, item := range keysFile.Decls {
 		// Check if this is a GenDecl and if it has at least 1 spec
 		genDecl, ok := item.(*ast.GenDecl)
@@ -68,7 +86,7 @@ func main() {
 		name := valSpec.Names[0].Name
 		event, ok := index[name]
 		if !ok {
-			fmt.Fprintf(os.Stderr, "WARNING: event.%s is defined in event/events.go, but we couldn't find the struct!\n", name)
+			fmt.Fprintf(os.Stderr, "WARNING: event.%s is defined in %s, but we couldn't find the struct!\n", name, filename)
 			continue
 		}
 
@@ -78,19 +96,8 @@ func main() {
 		}
 
 		event.Docs = &doc
+		event.disgordEvent = disgordEvents
 	}
-
-	for _, event := range events {
-		if event.Docs == nil {
-			fmt.Fprintf(os.Stderr, "WARNING: %s is defined in events.go, but has no docs in event/events.go!\n", event.varName)
-		}
-	}
-
-	// And finally pass the event information to different templates to generate some files
-	makeFile(events, "internal/generate/events/events.gohtml", "events_gen.go")
-	makeFile(events, "internal/generate/events/events_internal.gohtml", "internal/event/events_gen.go")
-	makeFile(events, "internal/generate/events/cache.gohtml", "cache_gen.go")
-	makeFile(events, "internal/generate/events/reactor.gotpl", "reactor_gen.go")
 }
 
 func makeFile(events []*eventName, tplFile, target string) {
@@ -116,8 +123,9 @@ func makeFile(events []*eventName, tplFile, target string) {
 }
 
 type eventName struct {
-	varName string
-	Docs    *string
+	varName      string
+	Docs         *string
+	disgordEvent bool
 }
 
 func (e eventName) LowerCaseFirst() string {
@@ -129,6 +137,17 @@ func (e eventName) String() string {
 }
 
 func (e eventName) IsDiscordEvent() bool {
+	return e.Docs != nil && !e.disgordEvent
+}
+
+// IsDisgordEvent is true for events that are emitted by Disgord itself, and not received from Discord.
+// These events bypass the cache and are not part of the intents calculation.
+func (e eventName) IsDisgordEvent() bool {
+	return e.Docs != nil && e.disgordEvent
+}
+
+// IsEvent is true for any event that can be handled by the reactor.
+func (e eventName) IsEvent() bool {
 	return e.Docs != nil
 }
 
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"net/http"
-	"strconv"
 	"sync"
 	"time"
 
@@ -13,6 +12,7 @@ import (
 
 func newLeakyBucket(global *ltBucket) (b *ltBucket) {
 	b = &ltBucket{
+		limit:     -1,
 		remaining: -1,
 		resetTime: time.Now(),
 		global:    global,
@@ -31,6 +31,7 @@ type ltBucket struct {
 
 	queue util.TicketQueue // Ticket => Token
 
+	limit            int       // requests per reset
 	remaining        int       // remaining requests
 	resetTime        time.Time // affected by time diff
 	discordResetTime time.Time // unaffected by time diff
@@ -40,16 +41,30 @@ type ltBucket struct {
 	// this bucket is global if this.global is nil or this == this.global
 	global      *ltBucket
 	usingGlobal bool
+
+	// waiting holds the number of queued requests per priority, of every bucket using this global bucket
+	waiting map[Priority]int
 }
 
 var _ RESTBucket = (*ltBucket)(nil)
 
+// Hash returns the Discord bucket hash, or an empty string when it is unknown.
+func (b *ltBucket) Hash() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.hash
+}
+
 func (b *ltBucket) AcquireLock() (locked bool) {
+	return b.acquireLock(PriorityNormal)
+}
+
+func (b *ltBucket) acquireLock(priority Priority) (locked bool) {
 	if locked = b.atomicLock.AcquireLock(); !locked {
 		return false
 	}
 
-	if _, err := b.SelectiveGlobalLock(); err != nil {
+	if _, err := b.SelectiveGlobalLock(priority); err != nil {
 		b.atomicLock.Unlock()
 		return false
 	}
@@ -57,12 +72,18 @@ func (b *ltBucket) AcquireLock() (locked bool) {
 	return true
 }
 
-func (b *ltBucket) SelectiveGlobalLock() (locked bool, err error) {
+// SelectiveGlobalLock acquires the global lock when the global rate limit is active. While it is active, a request
+// of another bucket with a higher priority gets the global lock first.
+func (b *ltBucket) SelectiveGlobalLock(priority Priority) (locked bool, err error) {
 	if b != b.global {
 		// peek global ltBucket
 		b.global.mu.RLock()
 		globalLock := b.global.active()
+		yield := globalLock && b.global.waitingAbove(priority)
 		b.global.mu.RUnlock()
+		if yield {
+			return false, errors.New("a request with a higher priority is waiting for the global lock")
+		}
 		// TODO: can this cause http 429?
 		if globalLock {
 			// so check if the globalLock has changed since the read
@@ -91,21 +112,29 @@ func (b *ltBucket) Transaction(ctx context.Context, do bucketTransaction) (resp
 	// reqA = /guilds/1/members?limit=100
 	// reqB = /guilds/1/members?limit=10
 	// reqB is a subset of A, and therefore reqA can create a response for reqB locally (must be deep copy - djp)
-	token := b.queue.NewTicket()
+	priority := PriorityFromContext(ctx)
+	token := b.queue.NewPriorityTicket(int(priority))
+	acquireLock := func() bool {
+		return b.acquireLock(priority)
+	}
+
+	b.waitForGlobal(priority, 1)
 	for {
 		select {
 		case <-ctx.Done():
+			b.waitForGlobal(priority, -1)
 			b.queue.Delete(token)
 			return nil, nil, errors.New("time out")
 		case <-time.After(10 * time.Millisecond):
 			// TODO-perf: this wastes a lot of CPU usage
 		}
 
-		if !b.queue.Next(token, b.AcquireLock) {
+		if !b.queue.Next(token, acquireLock) {
 			continue
 		}
 		break
 	}
+	b.waitForGlobal(priority, -1)
 	defer b.atomicLock.Unlock()
 	if b.usingGlobal {
 		defer b.global.atomicLock.Unlock()
@@ -125,9 +154,11 @@ func (b *ltBucket) Transaction(ctx context.Context, do bucketTransaction) (resp
 	// check if rate limited and try to wait it out
 	var wait time.Duration
 	now := time.Now()
+	bucket.mu.RLock()
 	if bucket.resetTime.After(now) && bucket.remaining == 0 {
 		wait = bucket.resetTime.Sub(now)
 	}
+	bucket.mu.RUnlock()
 	if deadline, ok := ctx.Deadline(); ok && deadline.Before(time.Now().Add(wait)) {
 		return nil, nil, errors.New("time out, bucket resets in " + wait.String())
 	}
@@ -145,8 +176,12 @@ func (b *ltBucket) Transaction(ctx context.Context, do bucketTransaction) (resp
 
 	// update ltBucket info
 	// reduce remaining if needed
-	if !b.updateAfterRequest(resp.Header, resp.StatusCode) && bucket.remaining > 0 {
-		bucket.remaining--
+	if !b.updateAfterRequest(resp.Header, resp.StatusCode) {
+		bucket.mu.Lock()
+		if bucket.remaining > 0 {
+			bucket.remaining--
+		}
+		bucket.mu.Unlock()
 	}
 
 	return resp, body, nil
@@ -160,47 +195,23 @@ func (b *ltBucket) updateAfterRequest(header http.Header, statusCode int) (adjus
 		panic("headers were not normalized to use milliseconds")
 	}
 
-	// to synchronize the timestamp between the bot and the discord server
-	// we assume the current time is equal the header date
-	discordTime, err := HeaderToTime(header)
-	if err != nil {
-		discordTime = time.Now()
-	}
-
-	localTime := time.Now()
-	diff := localTime.Sub(discordTime)
-
-	var isGlobal bool
-	bucketHash := header.Get(XRateLimitBucket)
-	if _, ok := header[XRateLimitBucket]; ok && bucketHash == "" {
-		isGlobal = true
-	}
-	isGlobal = isGlobal || header.Get(XRateLimitGlobal) == "true"
+	info := parseRateLimitHeader(header)
+	isGlobal := info.global
+	bucketHash := info.bucketHash
+	discordTime := info.discordTime
+	reset := info.reset
+	discordReset := info.discordReset
+	remaining := info.remaining
 
 	// if this is not a 429 error we can determine if the local ltBucket is a global one or not
-	if statusCode != http.StatusTooManyRequests && b.hash == "" {
-		if isGlobal {
+	if statusCode != http.StatusTooManyRequests {
+		b.mu.Lock()
+		if b.hash == "" && isGlobal {
 			b.hash = GlobalHash
-		} else if bucketHash != "" {
+		} else if b.hash == "" && bucketHash != "" {
 			b.hash = bucketHash
 		}
-	}
-
-	var reset time.Time
-	var discordReset time.Time
-	var remaining int = -1
-	if resetStr := header.Get(XRateLimitReset); resetStr != "" {
-		epoch, _ := strconv.ParseInt(resetStr, 10, 64)
-		epoch *= int64(time.Millisecond) // ms => nano
-		reset = time.Unix(0, epoch+diff.Nanoseconds())
-		discordReset = time.Unix(0, epoch)
-	}
-
-	if remainingStr := header.Get(XRateLimitRemaining); remainingStr != "" {
-		remainingInt64, _ := strconv.ParseInt(remainingStr, 10, 64)
-		if remainingInt64 >= 0 {
-			remaining = int(remainingInt64)
-		}
+		b.mu.Unlock()
 	}
 
 	// update ltBucket reference to whatever the header regards
@@ -214,7 +225,9 @@ func (b *ltBucket) updateAfterRequest(header http.Header, statusCode int) (adjus
 		bucket.mu.Lock()
 		defer bucket.mu.Unlock()
 	} else {
-		bucket = b // no need to lock normal buckets
+		bucket = b
+		bucket.mu.Lock()
+		defer bucket.mu.Unlock()
 		if !(b.global == nil || b == b.global) && bucketHash != "" {
 			b.hash = bucketHash
 		}
@@ -230,6 +243,7 @@ func (b *ltBucket) updateAfterRequest(header http.Header, statusCode int) (adjus
 		bucket.resetTime = reset
 		bucket.discordResetTime = discordReset
 		bucket.remaining = remaining
+		bucket.limit = info.limit
 		bucket.updatedAt = discordTime
 		adjustedRemaining = true
 	} else if bucket.discordResetTime == discordReset {
@@ -244,6 +258,32 @@ func (b *ltBucket) updateAfterRequest(header http.Header, statusCode int) (adjus
 	return adjustedRemaining
 }
 
+// waitForGlobal registers a queued request with the global bucket, or removes it using a negative delta.
+func (b *ltBucket) waitForGlobal(priority Priority, delta int) {
+	if b.global == nil || b.global == b {
+		return
+	}
+
+	b.global.mu.Lock()
+	defer b.global.mu.Unlock()
+	if b.global.waiting == nil {
+		b.global.waiting = make(map[Priority]int)
+	}
+	if b.global.waiting[priority] += delta; b.global.waiting[priority] <= 0 {
+		delete(b.global.waiting, priority)
+	}
+}
+
+// waitingAbove reports whether a request with a higher priority is queued. Caller must hold the lock.
+func (b *ltBucket) waitingAbove(priority Priority) bool {
+	for p := range b.waiting {
+		if p > priority {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *ltBucket) active() bool {
 	return b.remaining >= 0 && !time.Now().After(b.resetTime)
 }
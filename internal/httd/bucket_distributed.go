@@ -0,0 +1,314 @@
+package httd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andersfylling/disgord/internal/util"
+	"github.com/andersfylling/disgord/json"
+	"go.uber.org/atomic"
+)
+
+const (
+	distributedPrefix      = "disgord:"
+	distributedProxyPrefix = distributedPrefix + "proxy:"
+	distributedStatePrefix = distributedPrefix + "bucket:"
+	distributedLockPrefix  = distributedPrefix + "lock:"
+)
+
+// distributedLockTTL releases the lock of a bucket when the process holding it dies mid request.
+const distributedLockTTL = 30 * time.Second
+
+var distributedOwners atomic.Uint64
+
+// NewDistributedManager creates a RESTBucketManager that keeps the bucket state, the hash mappings and the
+// global rate limit in the given store. Every process using the same store shares the rate limits, which
+// allows several bots or shard groups to use the same token. The processes should have synchronized clocks.
+//
+// Request priorities only order the requests of this process, as the processes race for the lock of a bucket
+// in the store.
+func NewDistributedManager(store KVStore) *DistributedManager {
+	return &DistributedManager{
+		store:  store,
+		owner:  strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + strconv.FormatUint(distributedOwners.Inc(), 36),
+		queues: make(map[string]*distributedQueue),
+	}
+}
+
+type DistributedManager struct {
+	store KVStore
+
+	// owner identifies the locks of this manager, each lock gets a unique suffix
+	owner string
+	locks atomic.Uint64
+
+	// queues order the requests of this process by priority, before they compete for the bucket lock
+	mu     sync.Mutex
+	queues map[string]*distributedQueue
+}
+
+var _ RESTBucketManager = (*DistributedManager)(nil)
+
+// distributedState is the rate limit state of a bucket, as stored in the key-value store.
+type distributedState struct {
+	Limit        int   `json:"limit"`
+	Remaining    int   `json:"remaining"`
+	Reset        int64 `json:"reset"`         // unix ms, affected by time diff
+	DiscordReset int64 `json:"discord_reset"` // unix ms, unaffected by time diff
+}
+
+func (r *DistributedManager) BucketGrouping() (group map[string][]string) {
+	group = make(map[string][]string)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	keys, err := r.store.Keys(ctx, distributedProxyPrefix)
+	if err != nil {
+		return group
+	}
+	for _, key := range keys {
+		hash, err := r.store.Get(ctx, key)
+		if err != nil || hash == nil {
+			continue
+		}
+		id := strings.TrimPrefix(key, distributedProxyPrefix)
+		group[string(hash)] = append(group[string(hash)], id)
+	}
+	return group
+}
+
+// ProxyID returns the discord bucket hash of the local hash, or the local hash if the bucket hash is unknown.
+func (r *DistributedManager) ProxyID(ctx context.Context, id string) (pID string, err error) {
+	hash, err := r.store.Get(ctx, distributedProxyPrefix+id)
+	if err != nil {
+		return "", err
+	}
+	if hash == nil {
+		return id, nil
+	}
+	return string(hash), nil
+}
+
+func (r *DistributedManager) Bucket(id string, cb func(bucket RESTBucket)) {
+	cb(&distributedBucket{manager: r, id: id})
+}
+
+// queue returns the local queue of the bucket
+func (r *DistributedManager) queue(pID string) *distributedQueue {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	q, ok := r.queues[pID]
+	if !ok {
+		q = &distributedQueue{}
+		r.queues[pID] = q
+	}
+	return q
+}
+
+func (r *DistributedManager) state(ctx context.Context, key string) (*distributedState, error) {
+	data, err := r.store.Get(ctx, key)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	state := &distributedState{}
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (r *DistributedManager) setState(ctx context.Context, key string, state *distributedState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	// the state is useless once the bucket has reset
+	ttl := time.Until(time.Unix(0, state.Reset*int64(time.Millisecond))) + time.Second
+	if ttl <= 0 {
+		return nil
+	}
+	return r.store.Set(ctx, key, data, ttl)
+}
+
+// distributedQueue lets a single request of this process at a time compete for the lock of a bucket, in the
+// order of their priorities.
+type distributedQueue struct {
+	tickets util.TicketQueue
+	lock    util.AtomicLock
+}
+
+// acquire blocks until the request is next in line. The lock must be released once the request completes.
+func (q *distributedQueue) acquire(ctx context.Context) error {
+	ticket := q.tickets.NewPriorityTicket(int(PriorityFromContext(ctx)))
+	for !q.tickets.Next(ticket, q.lock.AcquireLock) {
+		select {
+		case <-ctx.Done():
+			q.tickets.Delete(ticket)
+			return errors.New("time out")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return nil
+}
+
+// distributedBucket is a RESTBucket where the state lives in the store of the manager. The bucket is resolved
+// for every transaction, as another process might have learned the discord bucket hash in the meantime.
+type distributedBucket struct {
+	manager *DistributedManager
+	id      string
+	hash    string
+}
+
+var _ RESTBucket = (*distributedBucket)(nil)
+
+// Hash returns the Discord bucket hash, or an empty string when it is unknown.
+func (b *distributedBucket) Hash() string {
+	return b.hash
+}
+
+func (b *distributedBucket) lock(ctx context.Context, key, owner string) error {
+	for {
+		locked, err := b.manager.store.Lock(ctx, key, owner, distributedLockTTL)
+		if err != nil {
+			return err
+		}
+		if locked {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.New("time out")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// wait blocks until the bucket stored at key allows another request
+func (b *distributedBucket) wait(ctx context.Context, key string) error {
+	state, err := b.manager.state(ctx, key)
+	if err != nil || state == nil {
+		return err
+	}
+
+	var wait time.Duration
+	reset := time.Unix(0, state.Reset*int64(time.Millisecond))
+	if now := time.Now(); reset.After(now) && state.Remaining == 0 {
+		wait = reset.Sub(now)
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(time.Now().Add(wait)) {
+		return errors.New("time out, bucket resets in " + wait.String())
+	}
+	select {
+	case <-ctx.Done():
+		return errors.New("time out")
+	case <-time.After(wait):
+	}
+	return nil
+}
+
+func (b *distributedBucket) Transaction(ctx context.Context, do bucketTransaction) (resp *http.Response, body []byte, err error) {
+	m := b.manager
+	pID, err := m.ProxyID(ctx, b.id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pID != b.id {
+		b.hash = pID
+	}
+
+	queue := m.queue(pID)
+	if err = queue.acquire(ctx); err != nil {
+		return nil, nil, err
+	}
+	defer queue.lock.Unlock()
+
+	lockKey := distributedLockPrefix + pID
+	owner := m.owner + "-" + strconv.FormatUint(m.locks.Inc(), 36)
+	if err = b.lock(ctx, lockKey, owner); err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		// the lock expires by itself if the store can not be reached
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_ = m.store.Unlock(unlockCtx, lockKey, owner)
+		cancel()
+	}()
+
+	// check if rate limited and try to wait it out
+	if err = b.wait(ctx, distributedPrefix+GlobalHash); err != nil {
+		return nil, nil, err
+	}
+	if err = b.wait(ctx, distributedStatePrefix+pID); err != nil {
+		return nil, nil, err
+	}
+
+	// send request
+	resp, body, err = do()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = b.updateAfterRequest(ctx, pID, resp.Header, resp.StatusCode); err != nil {
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+// updateAfterRequest stores the latest rate limit info, and links the local hash to the discord bucket hash.
+//
+// Note! you must call NormalizeDiscordHeader before using this.
+func (b *distributedBucket) updateAfterRequest(ctx context.Context, pID string, header http.Header, statusCode int) error {
+	if normalized := header.Get(DisgordNormalizedHeader); normalized == "" {
+		panic("headers were not normalized to use milliseconds")
+	}
+	m := b.manager
+	info := parseRateLimitHeader(header)
+
+	key := distributedStatePrefix + pID
+	if info.global {
+		key = distributedPrefix + GlobalHash
+	} else if statusCode != http.StatusTooManyRequests && info.bucketHash != "" && info.bucketHash != pID {
+		if err := m.store.Set(ctx, distributedProxyPrefix+b.id, []byte(info.bucketHash), 0); err != nil {
+			return err
+		}
+		key = distributedStatePrefix + info.bucketHash
+	}
+
+	state, err := m.state(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if info.discordReset.Before(time.Unix(0, int64(time.Hour))) {
+		// no rate limit info, so reduce remaining if needed
+		if state != nil && state.Remaining > 0 {
+			state.Remaining--
+			return m.setState(ctx, key, state)
+		}
+		return nil
+	}
+
+	// use discord reset time, as the local reset can be different in ms or s per request.
+	discordReset := info.discordReset.UnixNano() / int64(time.Millisecond)
+	if state == nil || discordReset > state.DiscordReset {
+		state = &distributedState{
+			Limit:        info.limit,
+			Remaining:    info.remaining,
+			Reset:        info.reset.UnixNano() / int64(time.Millisecond),
+			DiscordReset: discordReset,
+		}
+	} else if state.DiscordReset == discordReset && (state.Remaining == -1 || state.Remaining > info.remaining) {
+		state.Remaining = info.remaining
+	} else if state.Remaining > 0 {
+		state.Remaining--
+	}
+	return m.setState(ctx, key, state)
+}
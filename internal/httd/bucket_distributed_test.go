@@ -0,0 +1,143 @@
+// +build !integration
+
+package httd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func rateLimitedResponse(hash string, remaining int, reset time.Time) *http.Response {
+	resp := &http.Response{
+		Header:     make(http.Header),
+		StatusCode: http.StatusOK,
+	}
+	resp.Header.Set(XRateLimitBucket, hash)
+	resp.Header.Set(XRateLimitLimit, "2")
+	resp.Header.Set(XRateLimitRemaining, strconv.Itoa(remaining))
+	resp.Header.Set(XRateLimitReset, strconv.FormatFloat(float64(reset.UnixNano())/float64(time.Second), 'f', 4, 64))
+	resp.Header.Set("date", time.Now().Format(time.RFC1123))
+	resp.Header, _ = NormalizeDiscordHeader(resp.StatusCode, resp.Header, nil)
+	return resp
+}
+
+func TestDistributedManager(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		_ = ServeStore(l, NewMemoryStore())
+	}()
+
+	storeA := NewTCPStore(l.Addr().String())
+	defer storeA.Close()
+	storeB := NewTCPStore(l.Addr().String())
+	defer storeB.Close()
+
+	a := NewDistributedManager(storeA)
+	b := NewDistributedManager(storeB)
+
+	id := "dlfjhdskfhjdskfjsd"
+	hash := "f56681194ebea036dd1297f1184bf7bd"
+	a.Bucket(id, func(bucket RESTBucket) {
+		_, _, err := bucket.Transaction(context.Background(), func() (*http.Response, []byte, error) {
+			return rateLimitedResponse(hash, 0, time.Now().Add(2*time.Hour)), nil, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if group := b.BucketGrouping(); len(group[hash]) != 1 || group[hash][0] != id {
+		t.Errorf("expected the bucket hash to be shared. Got %+v", group)
+	}
+
+	t.Run("same-endpoint", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		b.Bucket(id, func(bucket RESTBucket) {
+			_, _, err := bucket.Transaction(ctx, func() (*http.Response, []byte, error) {
+				return nil, nil, nil
+			})
+			if err == nil || !strings.Contains(err.Error(), "time out") {
+				t.Error("should have been rate limited")
+			}
+		})
+	})
+
+	t.Run("other-endpoint", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		b.Bucket("other", func(bucket RESTBucket) {
+			_, _, err := bucket.Transaction(ctx, func() (*http.Response, []byte, error) {
+				return rateLimitedResponse("other-hash", 1, time.Now().Add(time.Hour)), nil, nil
+			})
+			if err != nil {
+				t.Error("should not share the bucket with another endpoint", err)
+			}
+		})
+	})
+
+	t.Run("locked", func(t *testing.T) {
+		if locked, _ := storeA.Lock(context.Background(), distributedLockPrefix+"other-hash", "test", time.Minute); !locked {
+			t.Fatal("unable to acquire lock")
+		}
+		defer storeA.Unlock(context.Background(), distributedLockPrefix+"other-hash", "test")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		b.Bucket("other", func(bucket RESTBucket) {
+			_, _, err := bucket.Transaction(ctx, func() (*http.Response, []byte, error) {
+				t.Error("request was sent while the bucket was locked by another process")
+				return nil, nil, nil
+			})
+			if err == nil {
+				t.Error("expected a time out")
+			}
+		})
+	})
+}
+
+func TestDistributedManager_priority(t *testing.T) {
+	m := NewDistributedManager(NewMemoryStore())
+	queue := m.queue("test")
+	queue.lock.AcquireLock()
+
+	order := make(chan Priority, 3)
+	done := make(chan bool)
+	for _, priority := range []Priority{PriorityLow, PriorityNormal, PriorityHigh} {
+		priority := priority
+		go m.Bucket("test", func(bucket RESTBucket) {
+			_, _, _ = bucket.Transaction(WithPriority(context.Background(), priority), func() (*http.Response, []byte, error) {
+				order <- priority
+				return nil, nil, errors.New("no response")
+			})
+			done <- true
+		})
+		for queue.tickets.Len() < int(priority)+2 { // wait until the request is queued
+			time.Sleep(time.Millisecond)
+		}
+	}
+	queue.lock.Unlock()
+
+	for i := 0; i < 3; i++ {
+		<-done
+	}
+	close(order)
+	expects := []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+	var i int
+	for priority := range order {
+		if priority != expects[i] {
+			t.Errorf("request %d had priority %d, expected %d", i, priority, expects[i])
+		}
+		i++
+	}
+}
@@ -157,3 +157,67 @@ func TestLtBucket_RespectRateLimit(t *testing.T) {
 	})
 
 }
+
+func TestLtBucket_Transaction_priority(t *testing.T) {
+	bucket := newLeakyBucket(newLeakyBucket(nil))
+	bucket.atomicLock.AcquireLock()
+
+	order := make(chan Priority, 3)
+	done := make(chan bool)
+	for _, priority := range []Priority{PriorityLow, PriorityNormal, PriorityHigh} {
+		go func(priority Priority) {
+			_, _, _ = bucket.Transaction(WithPriority(context.Background(), priority), func() (*http.Response, []byte, error) {
+				order <- priority
+				return nil, nil, errors.New("no response")
+			})
+			done <- true
+		}(priority)
+		for bucket.queue.Len() < int(priority)+2 { // wait until the request is queued
+			time.Sleep(time.Millisecond)
+		}
+	}
+	bucket.atomicLock.Unlock()
+
+	for i := 0; i < 3; i++ {
+		<-done
+	}
+	close(order)
+	expects := []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+	var i int
+	for priority := range order {
+		if priority != expects[i] {
+			t.Errorf("request %d had priority %d, expected %d", i, priority, expects[i])
+		}
+		i++
+	}
+}
+
+func TestLtBucket_AcquireLock_globalPriority(t *testing.T) {
+	global := newLeakyBucket(nil)
+	global.remaining = 0
+	global.resetTime = time.Now().Add(time.Minute)
+
+	low := newLeakyBucket(global)
+	high := newLeakyBucket(global)
+	low.waitForGlobal(PriorityLow, 1)
+	high.waitForGlobal(PriorityHigh, 1)
+
+	if low.acquireLock(PriorityLow) {
+		t.Fatal("expected the low priority request to wait for the high priority request")
+	}
+	if !high.acquireLock(PriorityHigh) || !high.usingGlobal {
+		t.Fatal("expected the high priority request to get the global lock")
+	}
+	high.waitForGlobal(PriorityHigh, -1)
+	high.usingGlobal = false
+	high.atomicLock.Unlock()
+	global.atomicLock.Unlock()
+
+	if !low.acquireLock(PriorityLow) || !low.usingGlobal {
+		t.Error("expected the low priority request to get the global lock once no other request is waiting")
+	}
+	low.waitForGlobal(PriorityLow, -1)
+	if len(global.waiting) != 0 {
+		t.Errorf("expected no waiting requests, got %+v", global.waiting)
+	}
+}
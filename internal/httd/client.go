@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/andersfylling/disgord/json"
@@ -73,6 +74,12 @@ type ErrREST struct {
 	HTTPCode       int      `json:"-"`
 	Bucket         []string `json:"-"`
 	HashedEndpoint string   `json:"-"`
+
+	// Attempts is the number of times the request was sent. See RetryPolicy.
+	Attempts int `json:"-"`
+
+	// FieldErrors holds the validation errors of the request body, when Code is 50035 (Invalid Form Body).
+	FieldErrors []*FieldError `json:"-"`
 }
 
 var _ error = (*ErrREST)(nil)
@@ -81,6 +88,32 @@ func (e *ErrREST) Error() string {
 	return fmt.Sprintf("%s\n%s\n%s => %+v", e.Msg, e.Suggestion, e.HashedEndpoint, e.Bucket)
 }
 
+// Is allows the Discord JSON error code to be matched using errors.Is, eg. errors.Is(err, ErrorCode(10008)).
+func (e *ErrREST) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && e.Code != 0 && e.Code == int(code)
+}
+
+// ErrTransport is returned when the last attempt of a request failed before a response was received, such
+// as on a dropped connection. The underlying error can be retrieved using errors.Unwrap.
+type ErrTransport struct {
+	Err            error
+	HashedEndpoint string
+
+	// Attempts is the number of times the request was sent. See RetryPolicy.
+	Attempts int
+}
+
+var _ error = (*ErrTransport)(nil)
+
+func (e *ErrTransport) Error() string {
+	return fmt.Sprintf("%s => request failed after %d attempt(s): %s", e.HashedEndpoint, e.Attempts, e.Err)
+}
+
+func (e *ErrTransport) Unwrap() error {
+	return e.Err
+}
+
 type HttpClientDoer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
@@ -92,6 +125,10 @@ type Client struct {
 	httpClient                   HttpClientDoer
 	cancelRequestWhenRateLimited bool
 	buckets                      RESTBucketManager
+	retryPolicy                  *RetryPolicy
+	interceptors                 []Interceptor
+	onRateLimit                  func(evt *RateLimitEvent)
+	rateLimitDelayThreshold      time.Duration
 }
 
 func (c *Client) BucketGrouping() (group map[string][]string) {
@@ -159,11 +196,26 @@ func NewClient(conf *Config) (*Client, error) {
 		"Accept-Encoding": {"gzip"},
 	}
 
+	if conf.BaseURL == "" {
+		conf.BaseURL = BaseURL
+	}
+	conf.BaseURL = strings.TrimSuffix(conf.BaseURL, "/")
+
+	var retryPolicy *RetryPolicy
+	if conf.RetryPolicy != nil {
+		retryPolicy = conf.RetryPolicy.populateMissing()
+	}
+
 	return &Client{
-		url:        BaseURL + "/v" + strconv.Itoa(conf.APIVersion),
-		reqHeader:  header,
-		httpClient: conf.HttpClient,
-		buckets:    conf.RESTBucketManager,
+		url:          conf.BaseURL + "/v" + strconv.Itoa(conf.APIVersion),
+		reqHeader:    header,
+		httpClient:   conf.HttpClient,
+		buckets:      conf.RESTBucketManager,
+		retryPolicy:  retryPolicy,
+		interceptors: conf.Interceptors,
+
+		onRateLimit:             conf.OnRateLimit,
+		rateLimitDelayThreshold: conf.RateLimitDelayThreshold,
 	}, nil
 }
 
@@ -173,6 +225,10 @@ type Config struct {
 	APIVersion int
 	BotToken   string
 
+	// BaseURL is where requests are sent, without the API version. Defaults to BaseURL, but can point
+	// to a REST proxy that handles the rate limits for several processes.
+	BaseURL string
+
 	HttpClient HttpClientDoer
 
 	CancelRequestWhenRateLimited bool
@@ -180,6 +236,20 @@ type Config struct {
 	// RESTBucketManager stores all rate limit buckets and dictates the behaviour of how rate limiting is respected
 	RESTBucketManager RESTBucketManager
 
+	// RetryPolicy decides if failed requests should be sent again. Requests are not retried when nil.
+	RetryPolicy *RetryPolicy
+
+	// Interceptors wrap every request sent to Discord, see Interceptor.
+	Interceptors []Interceptor
+
+	// OnRateLimit is called for every http 429 response, and for every request that waited longer than
+	// RateLimitDelayThreshold for its bucket. It is called before the response is returned, so it should not block.
+	OnRateLimit func(evt *RateLimitEvent)
+
+	// RateLimitDelayThreshold is how long a request can wait for its bucket before OnRateLimit is called.
+	// Only http 429 responses are reported when 0.
+	RateLimitDelayThreshold time.Duration
+
 	// Header field: `User-Agent: DiscordBot ({Source}, {Version}) {Extra}`
 	UserAgentVersion   string
 	UserAgentSourceURL string
@@ -224,6 +294,48 @@ func (c *Client) decodeResponseBody(resp *http.Response) (body []byte, err error
 }
 
 func (c *Client) Do(ctx context.Context, r *Request) (resp *http.Response, body []byte, err error) {
+	resp, body, attempts, err := c.do(ctx, r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// check if request was successful
+	noDiff := resp.StatusCode == http.StatusNotModified
+	withinSuccessScope := 200 <= resp.StatusCode && resp.StatusCode < 300
+	if !(noDiff || withinSuccessScope) {
+		// not within successful http range
+		msg := "response was not within the successful http code range [200, 300). code: "
+		msg += strconv.Itoa(resp.StatusCode)
+
+		restErr := &ErrREST{
+			Msg:            msg,
+			Suggestion:     string(body),
+			HTTPCode:       resp.StatusCode,
+			Bucket:         c.buckets.BucketGrouping()[r.hashedEndpoint],
+			HashedEndpoint: r.hashedEndpoint,
+			Attempts:       attempts,
+		}
+
+		// store the Discord error if it exists
+		if len(body) > 0 {
+			_ = json.Unmarshal(body, restErr)
+			restErr.FieldErrors = parseFieldErrors(body)
+		}
+		return nil, nil, restErr
+	}
+
+	return resp, body, nil
+}
+
+// DoRaw sends the request like Do, but responses outside the successful http range are returned as is
+// instead of as an ErrREST. Useful for forwarding responses.
+func (c *Client) DoRaw(ctx context.Context, r *Request) (resp *http.Response, body []byte, err error) {
+	resp, body, _, err = c.do(ctx, r)
+	return resp, body, err
+}
+
+// do sends the request through the bucket of the endpoint, and retries it according to the retry policy.
+func (c *Client) do(ctx context.Context, r *Request) (resp *http.Response, body []byte, attempt int, err error) {
 	r.PopulateMissing()
 	if r.Body != nil && r.bodyReader == nil {
 		switch b := r.Body.(type) { // Determine the type of the passed body so we can treat it differently
@@ -232,19 +344,61 @@ func (c *Client) Do(ctx context.Context, r *Request) (resp *http.Response, body
 		default:
 			// If the type is unknown, possibly Marshal it as JSON
 			if r.ContentType != ContentTypeJSON {
-				return nil, nil, errors.New("unknown request body types and only be used in conjunction with httd.ContentTypeJSON")
+				return nil, nil, attempt, errors.New("unknown request body types and only be used in conjunction with httd.ContentTypeJSON")
 			}
 
 			if r.bodyReader, err = convertStructToIOReader(json.Marshal, r.Body); err != nil {
-				return nil, nil, err
+				return nil, nil, attempt, err
 			}
 		}
 	}
 
-	// create http request
+	// the body must be buffered such that it can be sent again
+	retryable := c.retryPolicy.retries(r.Method)
+	var payload []byte
+	if retryable && r.bodyReader != nil {
+		if payload, err = ioutil.ReadAll(r.bodyReader); err != nil {
+			return nil, nil, attempt, err
+		}
+		r.bodyReader = bytes.NewReader(payload)
+	}
+
+	var transportFailed bool
+	for {
+		attempt++
+		var transportErr error
+		resp, body, transportFailed, err = c.send(ctx, r, attempt)
+		if transportFailed {
+			transportErr = err
+		}
+		if !retryable || !c.retryPolicy.shouldRetry(attempt, resp, transportErr) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, attempt, ctx.Err()
+		case <-time.After(c.retryPolicy.backoff(attempt)):
+		}
+		if payload != nil {
+			r.bodyReader = bytes.NewReader(payload)
+		}
+	}
+	if transportFailed && err != nil {
+		err = &ErrTransport{Err: err, HashedEndpoint: r.hashedEndpoint, Attempts: attempt}
+	}
+	if err != nil {
+		return nil, nil, attempt, err
+	}
+	return resp, body, attempt, nil
+}
+
+// send creates a http request and sends it through the bucket of the endpoint. transportFailed is
+// true when the error came from the http client, rather than the bucket.
+func (c *Client) send(ctx context.Context, r *Request, attempt int) (resp *http.Response, body []byte, transportFailed bool, err error) {
 	req, err := http.NewRequestWithContext(ctx, r.Method.String(), c.url+r.Endpoint, r.bodyReader)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, false, err
 	}
 
 	header := copyHeader(c.reqHeader)
@@ -257,58 +411,61 @@ func (c *Client) Do(ctx context.Context, r *Request) (resp *http.Response, body
 	}
 	req.Header = header
 
-	// queue & send request
-	c.buckets.Bucket(r.hashedEndpoint, func(bucket RESTBucket) {
-		resp, body, err = bucket.Transaction(ctx, func() (*http.Response, []byte, error) {
-			resp, err := c.httpClient.Do(req)
-			if err != nil {
-				return nil, nil, err
-			}
-
-			// store the current timestamp
-			epochMs := time.Now().UnixNano() / int64(time.Millisecond)
-			resp.Header.Set(XDisgordNow, strconv.FormatInt(epochMs, 10))
-
-			// decode body
-			body, err := c.decodeResponseBody(resp)
-			_ = resp.Body.Close()
-			if err != nil {
-				return nil, nil, err
-			}
-
-			// normalize Discord header fields
-			resp.Header, err = NormalizeDiscordHeader(resp.StatusCode, resp.Header, body)
-			return resp, body, err
-		})
-	})
-	if err != nil {
-		return nil, nil, err
+	info := &RequestInfo{
+		Method:         r.Method.String(),
+		Endpoint:       r.Endpoint,
+		HashedEndpoint: r.hashedEndpoint,
+		Attempt:        attempt,
+		Header:         header,
 	}
+	roundTrip := intercept(c.interceptors, func(ctx context.Context, info *RequestInfo) (*http.Response, []byte, error) {
+		req := req.WithContext(ctx)
+		req.Header = info.Header
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			transportFailed = true
+			return nil, nil, err
+		}
 
-	// check if request was successful
-	noDiff := resp.StatusCode == http.StatusNotModified
-	withinSuccessScope := 200 <= resp.StatusCode && resp.StatusCode < 300
-	if !(noDiff || withinSuccessScope) {
-		// not within successful http range
-		msg := "response was not within the successful http code range [200, 300). code: "
-		msg += strconv.Itoa(resp.StatusCode)
+		// store the current timestamp
+		epochMs := time.Now().UnixNano() / int64(time.Millisecond)
+		resp.Header.Set(XDisgordNow, strconv.FormatInt(epochMs, 10))
 
-		err = &ErrREST{
-			Msg:            msg,
-			Suggestion:     string(body),
-			HTTPCode:       resp.StatusCode,
-			Bucket:         c.buckets.BucketGrouping()[r.hashedEndpoint],
-			HashedEndpoint: r.hashedEndpoint,
+		// decode body
+		body, err := c.decodeResponseBody(resp)
+		_ = resp.Body.Close()
+		if err != nil {
+			transportFailed = true
+			return nil, nil, err
 		}
 
-		// store the Discord error if it exists
-		if len(body) > 0 {
-			_ = json.Unmarshal(body, err)
+		if info.Bucket == "" {
+			info.Bucket = resp.Header.Get(XRateLimitBucket)
 		}
-		return nil, nil, err
+
+		// normalize Discord header fields
+		resp.Header, err = NormalizeDiscordHeader(resp.StatusCode, resp.Header, body)
+		return resp, body, err
+	})
+
+	// the bucket reads the priority from the context
+	if r.Priority != PriorityNormal {
+		ctx = WithPriority(ctx, r.Priority)
 	}
 
-	return resp, body, nil
+	// queue & send request
+	queued := time.Now()
+	c.buckets.Bucket(r.hashedEndpoint, func(bucket RESTBucket) {
+		resp, body, err = bucket.Transaction(ctx, func() (*http.Response, []byte, error) {
+			info.QueueWait = time.Since(queued)
+			if b, ok := bucket.(interface{ Hash() string }); ok {
+				info.Bucket = b.Hash()
+			}
+			return roundTrip(ctx, info)
+		})
+	})
+	c.reportRateLimit(info, resp)
+	return resp, body, transportFailed, err
 }
 
 // helper functions
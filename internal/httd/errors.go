@@ -1,6 +1,12 @@
 package httd
 
-import "time"
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/andersfylling/disgord/json"
+)
 
 type Error struct {
 	message string
@@ -16,3 +22,71 @@ func (e *Error) Error() string {
 var (
 	ErrRateLimited error = &Error{"rate limited", time.Unix(0, 0)}
 )
+
+// ErrorCode is a JSON error code returned by Discord, see ErrREST.Is.
+type ErrorCode int
+
+var _ error = ErrorCode(0)
+
+func (c ErrorCode) Error() string {
+	if description, ok := errorCodeDescriptions[c]; ok {
+		return description
+	}
+	return "Discord error code " + strconv.Itoa(int(c))
+}
+
+// FieldError is a validation error of a single field in the request body.
+type FieldError struct {
+	// Field is the path to the field, eg. "embed.fields.0.name"
+	Field   string `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// parseFieldErrors flattens the nested errors object from Discord, where every level is a field name or
+// an array index, and the validation errors are found under "_errors".
+func parseFieldErrors(body []byte) (fieldErrors []*FieldError) {
+	var content struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &content); err != nil || len(content.Errors) == 0 {
+		return nil
+	}
+
+	var walk func(field string, data json.RawMessage)
+	walk = func(field string, data json.RawMessage) {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return
+		}
+
+		for name, value := range fields {
+			if name != "_errors" {
+				if field != "" {
+					name = field + "." + name
+				}
+				walk(name, value)
+				continue
+			}
+
+			var errs []*FieldError
+			if err := json.Unmarshal(value, &errs); err != nil {
+				continue
+			}
+			for _, e := range errs {
+				e.Field = field
+				fieldErrors = append(fieldErrors, e)
+			}
+		}
+	}
+	walk("", content.Errors)
+
+	sort.SliceStable(fieldErrors, func(i, j int) bool {
+		return fieldErrors[i].Field < fieldErrors[j].Field
+	})
+	return fieldErrors
+}
@@ -0,0 +1,69 @@
+// +build !integration
+
+package httd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestErrREST_Is(t *testing.T) {
+	body := `{"code": 50035, "message": "Invalid Form Body", "errors": {
+		"content": {"_errors": [{"code": "BASE_TYPE_MAX_LENGTH", "message": "Must be 2000 or fewer in length."}]},
+		"embed": {"fields": {"0": {"name": {"_errors": [{"code": "BASE_TYPE_REQUIRED", "message": "This field is required"}]}}}}
+	}}`
+	client, err := NewClient(&Config{
+		APIVersion: 8,
+		BotToken:   "test",
+		HttpClient: httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header:     make(http.Header),
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		}),
+		UserAgentSourceURL: "test",
+		UserAgentVersion:   "test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = client.Do(context.Background(), &Request{Method: MethodPost, Endpoint: "/channels/1/messages"})
+	if !errors.Is(err, ErrorCode(50035)) {
+		t.Errorf("expected error code 50035, got %v", err)
+	}
+	if errors.Is(err, ErrorCode(10008)) {
+		t.Error("matched the wrong error code")
+	}
+
+	var restErr *ErrREST
+	if !errors.As(err, &restErr) {
+		t.Fatal("expected an ErrREST")
+	}
+	expects := []FieldError{
+		{Field: "content", Code: "BASE_TYPE_MAX_LENGTH", Message: "Must be 2000 or fewer in length."},
+		{Field: "embed.fields.0.name", Code: "BASE_TYPE_REQUIRED", Message: "This field is required"},
+	}
+	if len(restErr.FieldErrors) != len(expects) {
+		t.Fatalf("expected %d field errors, got %d", len(expects), len(restErr.FieldErrors))
+	}
+	for i, expect := range expects {
+		if *restErr.FieldErrors[i] != expect {
+			t.Errorf("expected %+v, got %+v", expect, *restErr.FieldErrors[i])
+		}
+	}
+}
+
+func TestErrorCode_Error(t *testing.T) {
+	if msg := ErrorCode(10008).Error(); msg != "Unknown message" {
+		t.Errorf("unexpected description %q", msg)
+	}
+	if msg := ErrorCode(1).Error(); msg != "Discord error code 1" {
+		t.Errorf("unexpected description %q", msg)
+	}
+}
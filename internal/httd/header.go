@@ -19,6 +19,7 @@ const (
 	XRateLimitReset         = "X-RateLimit-Reset"
 	XRateLimitResetAfter    = "X-RateLimit-Reset-After"
 	XRateLimitGlobal        = "X-RateLimit-Global"
+	XRateLimitScope         = "X-RateLimit-Scope"
 	RateLimitRetryAfter     = "Retry-After"
 	DisgordNormalizedHeader = "X-Disgord-Normalized-Kufdsfksduhf-S47yf"
 	XDisgordNow             = "X-Disgord-Now-fsagkhf"
@@ -113,3 +114,60 @@ func NormalizeDiscordHeader(statusCode int, header http.Header, body []byte) (h
 	header.Set(DisgordNormalizedHeader, "true")
 	return header, nil
 }
+
+// rateLimitHeader holds the rate limit information of a normalized response header
+type rateLimitHeader struct {
+	bucketHash string
+	global     bool
+
+	// limit and remaining are -1 when unknown
+	limit     int
+	remaining int
+
+	// reset is adjusted for the time difference between Discord and the local clock,
+	// while discordReset is the reset given by Discord
+	reset        time.Time
+	discordReset time.Time
+	discordTime  time.Time
+}
+
+// parseRateLimitHeader extracts the rate limit information from a header. See NormalizeDiscordHeader.
+func parseRateLimitHeader(header http.Header) (info rateLimitHeader) {
+	// to synchronize the timestamp between the bot and the discord server
+	// we assume the current time is equal the header date
+	var err error
+	if info.discordTime, err = HeaderToTime(header); err != nil {
+		info.discordTime = time.Now()
+	}
+	diff := time.Now().Sub(info.discordTime)
+
+	info.bucketHash = header.Get(XRateLimitBucket)
+	if _, ok := header[XRateLimitBucket]; ok && info.bucketHash == "" {
+		info.global = true
+	}
+	info.global = info.global || header.Get(XRateLimitGlobal) == "true"
+
+	if resetStr := header.Get(XRateLimitReset); resetStr != "" {
+		epoch, _ := strconv.ParseInt(resetStr, 10, 64)
+		epoch *= int64(time.Millisecond) // ms => nano
+		info.reset = time.Unix(0, epoch+diff.Nanoseconds())
+		info.discordReset = time.Unix(0, epoch)
+	}
+
+	info.limit = -1
+	if limitStr := header.Get(XRateLimitLimit); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			info.limit = limit
+		}
+	}
+
+	info.remaining = -1
+	if remainingStr := header.Get(XRateLimitRemaining); remainingStr != "" {
+		remainingInt64, _ := strconv.ParseInt(remainingStr, 10, 64)
+		if remainingInt64 >= 0 {
+			info.remaining = int(remainingInt64)
+		}
+	}
+
+	return info
+}
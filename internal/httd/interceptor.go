@@ -0,0 +1,48 @@
+package httd
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes a single attempt of a REST request, after it has waited in its bucket.
+type RequestInfo struct {
+	Method         string
+	Endpoint       string
+	HashedEndpoint string
+
+	// Bucket is the Discord bucket hash of the endpoint. It is empty until Discord has given the hash,
+	// in which case it is populated from the response once the request returns.
+	Bucket string
+
+	// Attempt starts at 1, and increases for every retry. See RetryPolicy.
+	Attempt int
+
+	// QueueWait is the time spent waiting for the bucket before the request was sent.
+	QueueWait time.Duration
+
+	// Header is sent with the request, and can be modified to eg. add a request id.
+	Header http.Header
+}
+
+// RoundTrip sends the request described by the info, and returns the response with the decoded body.
+type RoundTrip func(ctx context.Context, info *RequestInfo) (*http.Response, []byte, error)
+
+// Interceptor wraps every request that is sent to Discord, and must call next to send the request.
+// The response body size is the length of the returned body.
+//
+// Interceptors are called once the rate limits allow the request to be sent, so any time spent in an
+// interceptor is spent holding the bucket.
+type Interceptor func(ctx context.Context, info *RequestInfo, next RoundTrip) (*http.Response, []byte, error)
+
+// intercept sends the request through every interceptor, where the first interceptor is the outermost.
+func intercept(interceptors []Interceptor, roundTrip RoundTrip) RoundTrip {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], roundTrip
+		roundTrip = func(ctx context.Context, info *RequestInfo) (*http.Response, []byte, error) {
+			return interceptor(ctx, info, next)
+		}
+	}
+	return roundTrip
+}
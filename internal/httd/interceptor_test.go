@@ -0,0 +1,82 @@
+// +build !integration
+
+package httd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type httpClientFunc func(req *http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClient_Do_interceptors(t *testing.T) {
+	var requestIDs []string
+	doer := httpClientFunc(func(req *http.Request) (*http.Response, error) {
+		requestIDs = append(requestIDs, req.Header.Get("X-Request-Id"))
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":"1"}`)),
+		}
+		resp.Header.Set(XRateLimitBucket, "abc")
+		return resp, nil
+	})
+
+	var order []string
+	var infos []RequestInfo
+	client, err := NewClient(&Config{
+		APIVersion:         8,
+		BotToken:           "test",
+		HttpClient:         doer,
+		UserAgentSourceURL: "test",
+		UserAgentVersion:   "test",
+		Interceptors: []Interceptor{
+			func(ctx context.Context, info *RequestInfo, next RoundTrip) (*http.Response, []byte, error) {
+				order = append(order, "first")
+				info.Header.Set("X-Request-Id", "42")
+				resp, body, err := next(ctx, info)
+				infos = append(infos, *info)
+				if resp == nil || resp.StatusCode != http.StatusOK || len(body) != 10 {
+					t.Errorf("unexpected response %+v, %s", resp, body)
+				}
+				return resp, body, err
+			},
+			func(ctx context.Context, info *RequestInfo, next RoundTrip) (*http.Response, []byte, error) {
+				order = append(order, "second")
+				return next(ctx, info)
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, _, err = client.Do(context.Background(), &Request{Endpoint: "/channels/1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(order) != 4 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("interceptors were called in the wrong order: %+v", order)
+	}
+	if len(requestIDs) != 2 || requestIDs[0] != "42" {
+		t.Errorf("header changes were not sent: %+v", requestIDs)
+	}
+	for _, info := range infos {
+		if info.HashedEndpoint != "GET:/channels/1" || info.Attempt != 1 || info.Bucket != "abc" {
+			t.Errorf("unexpected request info %+v", info)
+		}
+		if info.QueueWait < 0 || info.QueueWait > time.Second {
+			t.Errorf("unexpected queue wait %s", info.QueueWait)
+		}
+	}
+}
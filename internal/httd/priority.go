@@ -0,0 +1,31 @@
+package httd
+
+import "context"
+
+// Priority decides the order in which queued requests are sent. Requests with a higher priority are sent
+// before any queued request with a lower priority in the same bucket, and before the requests of other
+// buckets while the global rate limit is active. Requests with the same priority are sent in the order
+// they were queued.
+type Priority int
+
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+type priorityCtxKey struct{}
+
+// WithPriority returns a context that gives every request using it the priority, unless the request
+// specifies its own priority.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityCtxKey{}, priority)
+}
+
+// PriorityFromContext returns the priority given by WithPriority, or PriorityNormal.
+func PriorityFromContext(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityCtxKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityNormal
+}
@@ -0,0 +1,208 @@
+package httd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BucketState is a snapshot of a rate limit bucket.
+type BucketState struct {
+	// Hash is the Discord bucket hash, which is empty until Discord has given one.
+	Hash string
+
+	// Endpoints holds the hashed endpoints that share the bucket.
+	Endpoints []string
+
+	// Limit and Remaining are -1 when unknown.
+	Limit     int
+	Remaining int
+	Reset     time.Time
+
+	// Queued is the number of requests waiting for the bucket.
+	Queued int
+}
+
+// RateLimitState is a snapshot of the REST rate limits.
+type RateLimitState struct {
+	Global BucketState
+
+	// GlobalLocked is true while every request waits for the global rate limit to reset.
+	GlobalLocked bool
+
+	Buckets []BucketState
+}
+
+// RateLimitStater is implemented by a RESTBucketManager that can report the state of its buckets.
+type RateLimitStater interface {
+	RateLimitState() *RateLimitState
+}
+
+func sortBucketStates(states []BucketState) {
+	for i := range states {
+		sort.Strings(states[i].Endpoints)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].Hash != states[j].Hash {
+			return states[i].Hash < states[j].Hash
+		}
+		return strings.Join(states[i].Endpoints, ",") < strings.Join(states[j].Endpoints, ",")
+	})
+}
+
+func (b *ltBucket) state() BucketState {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return BucketState{
+		Hash:      b.hash,
+		Limit:     b.limit,
+		Remaining: b.remaining,
+		Reset:     b.resetTime,
+		Queued:    b.queue.Len(),
+	}
+}
+
+var _ RateLimitStater = (*Manager)(nil)
+
+func (r *Manager) RateLimitState() *RateLimitState {
+	r.mu.RLock()
+	endpoints := make(map[*ltBucket][]string)
+	for id, pID := range r.proxy {
+		if bucket, ok := r.buckets[pID]; ok {
+			endpoints[bucket] = append(endpoints[bucket], id)
+		}
+	}
+	r.mu.RUnlock()
+
+	state := &RateLimitState{
+		Global:  r.global.state(),
+		Buckets: make([]BucketState, 0, len(endpoints)),
+	}
+	state.GlobalLocked = state.Global.Remaining == 0 && state.Global.Reset.After(time.Now())
+	for bucket, ids := range endpoints {
+		s := bucket.state()
+		s.Endpoints = ids
+		state.Buckets = append(state.Buckets, s)
+	}
+	sortBucketStates(state.Buckets)
+	return state
+}
+
+var _ RateLimitStater = (*DistributedManager)(nil)
+
+// RateLimitState returns the buckets with a known Discord bucket hash. The queued requests are unknown,
+// as they are spread across processes.
+func (r *DistributedManager) RateLimitState() *RateLimitState {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	toBucketState := func(hash string, s *distributedState) BucketState {
+		state := BucketState{Hash: hash, Limit: -1, Remaining: -1}
+		if s != nil {
+			state.Limit = s.Limit
+			state.Remaining = s.Remaining
+			state.Reset = time.Unix(0, s.Reset*int64(time.Millisecond))
+		}
+		return state
+	}
+
+	global, _ := r.state(ctx, distributedPrefix+GlobalHash)
+	state := &RateLimitState{
+		Global: toBucketState(GlobalHash, global),
+	}
+	state.GlobalLocked = state.Global.Remaining == 0 && state.Global.Reset.After(time.Now())
+
+	for hash, ids := range r.BucketGrouping() {
+		s, _ := r.state(ctx, distributedStatePrefix+hash)
+		bucket := toBucketState(hash, s)
+		bucket.Endpoints = ids
+		state.Buckets = append(state.Buckets, bucket)
+	}
+	sortBucketStates(state.Buckets)
+	return state
+}
+
+// RateLimitState returns a snapshot of the rate limits, as long as the RESTBucketManager implements RateLimitStater.
+func (c *Client) RateLimitState() (*RateLimitState, error) {
+	if stater, ok := c.buckets.(RateLimitStater); ok {
+		return stater.RateLimitState(), nil
+	}
+	return nil, errors.New("the RESTBucketManager does not implement RateLimitStater")
+}
+
+// RateLimitScope is the scope of a rate limit given by Discord in the X-RateLimit-Scope header.
+type RateLimitScope string
+
+const (
+	// RateLimitScopeUser is a rate limit for the bot.
+	RateLimitScopeUser RateLimitScope = "user"
+	// RateLimitScopeGlobal is the global rate limit for the bot.
+	RateLimitScopeGlobal RateLimitScope = "global"
+	// RateLimitScopeShared is a rate limit on a resource shared with others, such as a busy channel.
+	// Shared rate limits do not count towards invalid requests.
+	RateLimitScopeShared RateLimitScope = "shared"
+)
+
+// RateLimitEvent describes a request that was rate limited by Discord, or was delayed by the local
+// rate limits for longer than Config.RateLimitDelayThreshold.
+type RateLimitEvent struct {
+	Method         string
+	Endpoint       string
+	HashedEndpoint string
+	Bucket         string
+
+	// StatusCode is http.StatusTooManyRequests when Discord rate limited the request. Otherwise the
+	// request was delayed, and the response status is given.
+	StatusCode int
+
+	// Scope is the scope of the rate limit, and is only given for http.StatusTooManyRequests.
+	Scope RateLimitScope
+
+	// QueueWait is the time spent waiting for the bucket before the request was sent.
+	QueueWait time.Duration
+
+	// RetryAfter is the time until the bucket resets, and is only given for http.StatusTooManyRequests.
+	RetryAfter time.Duration
+}
+
+// Limited returns true when Discord responded with http.StatusTooManyRequests.
+func (e *RateLimitEvent) Limited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// reportRateLimit calls the rate limit callback if the request was rate limited by Discord or delayed.
+func (c *Client) reportRateLimit(info *RequestInfo, resp *http.Response) {
+	if c.onRateLimit == nil || resp == nil {
+		return
+	}
+	limited := resp.StatusCode == http.StatusTooManyRequests
+	delayed := c.rateLimitDelayThreshold > 0 && info.QueueWait >= c.rateLimitDelayThreshold
+	if !limited && !delayed {
+		return
+	}
+
+	evt := &RateLimitEvent{
+		Method:         info.Method,
+		Endpoint:       info.Endpoint,
+		HashedEndpoint: info.HashedEndpoint,
+		Bucket:         info.Bucket,
+		StatusCode:     resp.StatusCode,
+		QueueWait:      info.QueueWait,
+	}
+	if limited {
+		rateLimit := parseRateLimitHeader(resp.Header)
+		evt.Scope = RateLimitScope(resp.Header.Get(XRateLimitScope))
+		if evt.Scope == "" && rateLimit.global {
+			evt.Scope = RateLimitScopeGlobal
+		} else if evt.Scope == "" {
+			evt.Scope = RateLimitScopeUser
+		}
+		if wait := time.Until(rateLimit.reset); wait > 0 {
+			evt.RetryAfter = wait
+		}
+	}
+	c.onRateLimit(evt)
+}
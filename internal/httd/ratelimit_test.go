@@ -2,6 +2,15 @@
 
 package httd
 
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
 // import (
 // 	"fmt"
 // 	"net/http"
@@ -85,3 +94,66 @@ package httd
 // 		t.Error("was not rate limited on a global scale")
 // 	}
 // }
+
+func TestClient_RateLimitState(t *testing.T) {
+	var statusCodes = []int{http.StatusOK, http.StatusTooManyRequests}
+	doer := httpClientFunc(func(req *http.Request) (*http.Response, error) {
+		resp := &http.Response{
+			StatusCode: statusCodes[0],
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+		}
+		statusCodes = statusCodes[1:]
+		resp.Header.Set(XRateLimitBucket, "abc")
+		resp.Header.Set(XRateLimitLimit, "5")
+		resp.Header.Set(XRateLimitRemaining, "3")
+		resp.Header.Set(XRateLimitResetAfter, "1.5")
+		if resp.StatusCode == http.StatusTooManyRequests {
+			resp.Header.Set(XRateLimitScope, "shared")
+		}
+		return resp, nil
+	})
+
+	var events []*RateLimitEvent
+	client, err := NewClient(&Config{
+		APIVersion:         8,
+		BotToken:           "test",
+		HttpClient:         doer,
+		UserAgentSourceURL: "test",
+		UserAgentVersion:   "test",
+		OnRateLimit: func(evt *RateLimitEvent) {
+			events = append(events, evt)
+		},
+		RateLimitDelayThreshold: time.Nanosecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, _ = client.Do(context.Background(), &Request{Endpoint: "/channels/1"})
+	state, err := client.RateLimitState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Buckets) != 1 {
+		t.Fatalf("expected one bucket, got %+v", state.Buckets)
+	}
+	bucket := state.Buckets[0]
+	if bucket.Hash != "abc" || bucket.Limit != 5 || bucket.Remaining != 3 || bucket.Endpoints[0] != "GET:/channels/1" {
+		t.Errorf("unexpected bucket state %+v", bucket)
+	}
+	if state.GlobalLocked {
+		t.Error("global rate limit should not be locked")
+	}
+
+	_, _, _ = client.Do(context.Background(), &Request{Endpoint: "/channels/1"})
+	if len(events) != 2 {
+		t.Fatalf("expected a delay and a 429 event, got %d", len(events))
+	}
+	if events[0].Limited() || events[0].QueueWait <= 0 {
+		t.Errorf("expected a delayed request, got %+v", events[0])
+	}
+	if evt := events[1]; !evt.Limited() || evt.Scope != RateLimitScopeShared || evt.Bucket != "abc" || evt.RetryAfter <= 0 || evt.RetryAfter > 1500*time.Millisecond {
+		t.Errorf("unexpected rate limit event %+v", evt)
+	}
+}
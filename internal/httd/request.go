@@ -42,6 +42,9 @@ type Request struct {
 	// Reason is a X-Audit-Log-Reason header field that will show up on the audit log for this action.
 	Reason string
 
+	// Priority overrides the priority given by the context, when it is not PriorityNormal. See WithPriority.
+	Priority Priority
+
 	bodyReader     io.Reader
 	hashedEndpoint string
 }
@@ -0,0 +1,107 @@
+package httd
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy decides whether a failed request is sent again. A request is retried on transport errors,
+// such as a dropped connection, and on the given http status codes. Every retry goes through the bucket
+// of the request, so the rate limits are respected.
+//
+// POST requests are not idempotent, as retrying eg. a message creation might create duplicates. They
+// are therefore only retried when http.MethodPost is added to Methods.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first request. 0 or 1 disables retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, which doubles for every retry. Defaults to 250ms.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts. Defaults to 5s.
+	MaxDelay time.Duration
+
+	// Methods holds the http methods that can be retried. Defaults to GET, PUT, PATCH and DELETE.
+	Methods []string
+
+	// StatusCodes holds the http status codes that can be retried. Defaults to 500, 502, 503 and 504.
+	StatusCodes []int
+}
+
+// DefaultRetryPolicy retries idempotent requests up to three times.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+}
+
+// populateMissing returns a copy of the policy where every unset field holds the default value.
+func (p RetryPolicy) populateMissing() *RetryPolicy {
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = 250 * time.Millisecond
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 5 * time.Second
+	}
+	if p.Methods == nil {
+		p.Methods = []string{http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	}
+	if p.StatusCodes == nil {
+		p.StatusCodes = []int{
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		}
+	}
+	return &p
+}
+
+// retries checks if requests with the given method can be retried at all
+func (p *RetryPolicy) retries(method httpMethod) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+	for _, m := range p.Methods {
+		if m == method.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRetry checks if an attempt failed in a way that can be retried. A nil response
+// means the request failed before a response was received.
+func (p *RetryPolicy) shouldRetry(attempt int, resp *http.Response, transportErr error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if transportErr != nil {
+		return !errors.Is(transportErr, context.Canceled) && !errors.Is(transportErr, context.DeadlineExceeded)
+	}
+	if resp == nil {
+		return false
+	}
+	for _, code := range p.StatusCodes {
+		if code == resp.StatusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the next attempt, using exponential backoff with jitter.
+// The delay is between half and the full exponential delay.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
@@ -0,0 +1,122 @@
+// +build !integration
+
+package httd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type httpClientMock struct {
+	responses []int // status codes, 0 results in a transport error
+	bodies    []string
+}
+
+func (m *httpClientMock) Do(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		body, _ := ioutil.ReadAll(req.Body)
+		m.bodies = append(m.bodies, string(body))
+	}
+
+	status := m.responses[0]
+	m.responses = m.responses[1:]
+	if status == 0 {
+		return nil, errors.New("connection reset by peer")
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+	}, nil
+}
+
+func TestClient_Do_retry(t *testing.T) {
+	newClient := func(mock *httpClientMock) *Client {
+		client, err := NewClient(&Config{
+			APIVersion:         8,
+			BotToken:           "test",
+			HttpClient:         mock,
+			UserAgentSourceURL: "test",
+			UserAgentVersion:   "test",
+			RetryPolicy: &RetryPolicy{
+				MaxAttempts: 3,
+				BaseDelay:   time.Millisecond,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	t.Run("transient", func(t *testing.T) {
+		mock := &httpClientMock{responses: []int{0, http.StatusBadGateway, http.StatusOK}}
+		_, _, err := newClient(mock).Do(context.Background(), &Request{
+			Method:      MethodPatch,
+			Endpoint:    "/channels/1",
+			Body:        map[string]string{"name": "test"},
+			ContentType: ContentTypeJSON,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mock.responses) != 0 {
+			t.Errorf("expected 3 attempts, got %d", 3-len(mock.responses))
+		}
+		for _, body := range mock.bodies {
+			if body != mock.bodies[0] || body == "" {
+				t.Errorf("every attempt must send the same body, got %+v", mock.bodies)
+				break
+			}
+		}
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		mock := &httpClientMock{responses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusBadGateway}}
+		_, _, err := newClient(mock).Do(context.Background(), &Request{Endpoint: "/channels/1"})
+		var errREST *ErrREST
+		if !errors.As(err, &errREST) {
+			t.Fatalf("expected a *ErrREST, got %v", err)
+		}
+		if errREST.Attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", errREST.Attempts)
+		}
+	})
+
+	t.Run("transport error", func(t *testing.T) {
+		mock := &httpClientMock{responses: []int{0, http.StatusBadGateway, 0}}
+		_, _, err := newClient(mock).Do(context.Background(), &Request{Endpoint: "/channels/1"})
+		var errTransport *ErrTransport
+		if !errors.As(err, &errTransport) {
+			t.Fatalf("expected a *ErrTransport, got %v", err)
+		}
+		if errTransport.Attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", errTransport.Attempts)
+		}
+		if errTransport.Err == nil || errTransport.Err.Error() != "connection reset by peer" {
+			t.Errorf("expected the transport error to be wrapped, got %v", errTransport.Err)
+		}
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		mock := &httpClientMock{responses: []int{http.StatusNotFound, http.StatusOK}}
+		_, _, err := newClient(mock).Do(context.Background(), &Request{Endpoint: "/channels/1"})
+		var errREST *ErrREST
+		if !errors.As(err, &errREST) || errREST.Attempts != 1 {
+			t.Errorf("expected a single attempt, got %v", err)
+		}
+	})
+
+	t.Run("post", func(t *testing.T) {
+		mock := &httpClientMock{responses: []int{http.StatusBadGateway, http.StatusOK}}
+		_, _, err := newClient(mock).Do(context.Background(), &Request{Method: MethodPost, Endpoint: "/channels/1/messages"})
+		if err == nil {
+			t.Error("POST requests must not be retried by default")
+		}
+	})
+}
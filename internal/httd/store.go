@@ -0,0 +1,320 @@
+package httd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andersfylling/disgord/json"
+)
+
+// KVStore is a key-value store shared between processes, such as Redis. It holds the rate limit state
+// of a DistributedManager, so every process sharing the store respects the same buckets.
+//
+// A zero ttl means the key never expires.
+type KVStore interface {
+	// Get returns nil when the key does not exist or has expired.
+	Get(ctx context.Context, key string) (value []byte, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Keys returns every key that starts with the prefix.
+	Keys(ctx context.Context, prefix string) (keys []string, err error)
+
+	// Lock acquires the lock if it is free or has expired, similar to Redis' SET NX PX.
+	Lock(ctx context.Context, key, owner string, ttl time.Duration) (acquired bool, err error)
+
+	// Unlock releases the lock if it is still held by the owner.
+	Unlock(ctx context.Context, key, owner string) error
+}
+
+type memoryEntry struct {
+	value   []byte
+	owner   string
+	expires time.Time
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// MemoryStore is a KVStore for a single process. It can stand in for Redis in tests, or be shared
+// with other processes using ServeStore.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+	locks   map[string]*memoryEntry
+}
+
+var _ KVStore = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*memoryEntry),
+		locks:   make(map[string]*memoryEntry),
+	}
+}
+
+func expiresAt(ttl time.Duration) (t time.Time) {
+	if ttl > 0 {
+		t = time.Now().Add(ttl)
+	}
+	return t
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	if entry.expired(time.Now()) {
+		delete(s.entries, key)
+		return nil, nil
+	}
+
+	value := make([]byte, len(entry.value))
+	copy(value, entry.value)
+	return value, nil
+}
+
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	v := make([]byte, len(value))
+	copy(v, value)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = &memoryEntry{value: v, expires: expiresAt(ttl)}
+	return nil
+}
+
+func (s *MemoryStore) Keys(_ context.Context, prefix string) (keys []string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range s.entries {
+		if entry.expired(now) {
+			delete(s.entries, key)
+		} else if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (s *MemoryStore) Lock(_ context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lock, ok := s.locks[key]; ok && !lock.expired(time.Now()) {
+		return false, nil
+	}
+	s.locks[key] = &memoryEntry{owner: owner, expires: expiresAt(ttl)}
+	return true, nil
+}
+
+func (s *MemoryStore) Unlock(_ context.Context, key, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lock, ok := s.locks[key]; ok && lock.owner == owner {
+		delete(s.locks, key)
+	}
+	return nil
+}
+
+// storeMessage is a single request or response of the tcp store protocol, sent as newline delimited json.
+type storeMessage struct {
+	Op    string   `json:"op,omitempty"`
+	Key   string   `json:"key,omitempty"`
+	Value []byte   `json:"value,omitempty"`
+	Owner string   `json:"owner,omitempty"`
+	TTL   int64    `json:"ttl,omitempty"` // ms
+	OK    bool     `json:"ok,omitempty"`
+	Keys  []string `json:"keys,omitempty"`
+	Err   string   `json:"err,omitempty"`
+}
+
+const (
+	storeOpGet    = "get"
+	storeOpSet    = "set"
+	storeOpKeys   = "keys"
+	storeOpLock   = "lock"
+	storeOpUnlock = "unlock"
+)
+
+// ServeStore shares a store with every TCPStore connecting to the listener. It blocks until the
+// listener is closed.
+func ServeStore(l net.Listener, store KVStore) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go serveStoreConn(conn, store)
+	}
+}
+
+func serveStoreConn(conn net.Conn, store KVStore) {
+	defer conn.Close()
+
+	ctx := context.Background()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4*1024), 4*1024*1024)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		req := &storeMessage{}
+		if err := json.Unmarshal(scanner.Bytes(), req); err != nil {
+			return
+		}
+
+		ttl := time.Duration(req.TTL) * time.Millisecond
+		res := &storeMessage{}
+		var err error
+		switch req.Op {
+		case storeOpGet:
+			res.Value, err = store.Get(ctx, req.Key)
+			res.OK = res.Value != nil
+		case storeOpSet:
+			err = store.Set(ctx, req.Key, req.Value, ttl)
+		case storeOpKeys:
+			res.Keys, err = store.Keys(ctx, req.Key)
+		case storeOpLock:
+			res.OK, err = store.Lock(ctx, req.Key, req.Owner, ttl)
+		case storeOpUnlock:
+			err = store.Unlock(ctx, req.Key, req.Owner)
+		default:
+			err = errors.New("unknown store operation " + req.Op)
+		}
+		if err != nil {
+			res.Err = err.Error()
+		}
+
+		if err = encoder.Encode(res); err != nil {
+			return
+		}
+	}
+}
+
+// TCPStore is a KVStore client for a store shared by ServeStore. Requests are sent one at a time over
+// a single connection, which is re-established after a failure.
+type TCPStore struct {
+	mu      sync.Mutex
+	addr    string
+	conn    net.Conn
+	scanner *bufio.Scanner
+}
+
+var _ KVStore = (*TCPStore)(nil)
+
+// NewTCPStore creates a client for the store served at addr. The connection is established on first use.
+func NewTCPStore(addr string) *TCPStore {
+	return &TCPStore{addr: addr}
+}
+
+// Close closes the connection to the store.
+func (s *TCPStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *TCPStore) do(ctx context.Context, req *storeMessage) (*storeMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+		if err != nil {
+			return nil, err
+		}
+		s.conn = conn
+		s.scanner = bufio.NewScanner(conn)
+		s.scanner.Buffer(make([]byte, 4*1024), 4*1024*1024)
+	}
+
+	deadline, _ := ctx.Deadline() // zero means no deadline
+	_ = s.conn.SetDeadline(deadline)
+
+	res, err := s.roundTrip(req)
+	if err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return nil, err
+	}
+	if res.Err != "" {
+		return nil, errors.New(res.Err)
+	}
+	return res, nil
+}
+
+func (s *TCPStore) roundTrip(req *storeMessage) (*storeMessage, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.conn.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+
+	if !s.scanner.Scan() {
+		if err = s.scanner.Err(); err == nil {
+			err = errors.New("store connection closed")
+		}
+		return nil, err
+	}
+	res := &storeMessage{}
+	if err = json.Unmarshal(s.scanner.Bytes(), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *TCPStore) Get(ctx context.Context, key string) ([]byte, error) {
+	res, err := s.do(ctx, &storeMessage{Op: storeOpGet, Key: key})
+	if err != nil || !res.OK {
+		return nil, err
+	}
+	if res.Value == nil {
+		res.Value = []byte{}
+	}
+	return res.Value, nil
+}
+
+func (s *TCPStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.do(ctx, &storeMessage{Op: storeOpSet, Key: key, Value: value, TTL: ttl.Milliseconds()})
+	return err
+}
+
+func (s *TCPStore) Keys(ctx context.Context, prefix string) ([]string, error) {
+	res, err := s.do(ctx, &storeMessage{Op: storeOpKeys, Key: prefix})
+	if err != nil {
+		return nil, err
+	}
+	return res.Keys, nil
+}
+
+func (s *TCPStore) Lock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	res, err := s.do(ctx, &storeMessage{Op: storeOpLock, Key: key, Owner: owner, TTL: ttl.Milliseconds()})
+	if err != nil {
+		return false, err
+	}
+	return res.OK, nil
+}
+
+func (s *TCPStore) Unlock(ctx context.Context, key, owner string) error {
+	_, err := s.do(ctx, &storeMessage{Op: storeOpUnlock, Key: key, Owner: owner})
+	return err
+}
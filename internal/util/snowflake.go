@@ -15,3 +15,6 @@ func GetSnowflake(v interface{}) (Snowflake, error) {
 func ParseSnowflakeString(v string) Snowflake {
 	return snowflake.ParseSnowflakeString(v)
 }
+
+// EpochDiscord is the unix time in milliseconds of the first second of 2015, see snowflake.EpochDiscord
+const EpochDiscord = snowflake.EpochDiscord
@@ -10,13 +10,24 @@ const (
 	NoTicket Ticket = -1
 )
 
+type queuedTicket struct {
+	ticket   Ticket
+	priority int
+}
+
+// TicketQueue serves tickets in order of priority, and first come first served within the same priority.
 type TicketQueue struct {
 	mu         sync.Mutex
-	tickets    []Ticket
+	tickets    []queuedTicket
 	nextTicket Ticket
 }
 
 func (q *TicketQueue) NewTicket() (ticket Ticket) {
+	return q.NewPriorityTicket(0)
+}
+
+// NewPriorityTicket queues a ticket in front of every ticket with a lower priority.
+func (q *TicketQueue) NewPriorityTicket(priority int) (ticket Ticket) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 	defer func() {
@@ -24,7 +35,13 @@ func (q *TicketQueue) NewTicket() (ticket Ticket) {
 	}()
 
 	ticket = q.nextTicket
-	q.tickets = append(q.tickets, ticket)
+	i := len(q.tickets)
+	for i > 0 && q.tickets[i-1].priority < priority {
+		i--
+	}
+	q.tickets = append(q.tickets, queuedTicket{})
+	copy(q.tickets[i+1:], q.tickets[i:])
+	q.tickets[i] = queuedTicket{ticket: ticket, priority: priority}
 
 	return ticket
 }
@@ -36,7 +53,7 @@ func (q *TicketQueue) Delete(ticket Ticket) {
 	var i int
 	var ok bool
 	for i = range q.tickets {
-		if q.tickets[i] == ticket {
+		if q.tickets[i].ticket == ticket {
 			ok = true
 			break
 		}
@@ -48,10 +65,17 @@ func (q *TicketQueue) Delete(ticket Ticket) {
 	if i == len(q.tickets)-1 {
 		q.tickets = q.tickets[:i]
 	} else {
-		q.tickets = append(q.tickets[i:], q.tickets[i+1:]...)
+		q.tickets = append(q.tickets[:i], q.tickets[i+1:]...)
 	}
 }
 
+// Len returns the number of tickets waiting in the queue.
+func (q *TicketQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.tickets)
+}
+
 func (q *TicketQueue) Next(ticket Ticket, cb func() bool) bool {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -60,7 +84,7 @@ func (q *TicketQueue) Next(ticket Ticket, cb func() bool) bool {
 		return false
 	}
 
-	if q.tickets[0] != ticket {
+	if q.tickets[0].ticket != ticket {
 		return false
 	}
 
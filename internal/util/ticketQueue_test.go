@@ -0,0 +1,48 @@
+// +build !integration
+
+package util
+
+import "testing"
+
+func TestTicketQueue_priority(t *testing.T) {
+	q := TicketQueue{}
+	normal1 := q.NewTicket()
+	low := q.NewPriorityTicket(-1)
+	normal2 := q.NewTicket()
+	high := q.NewPriorityTicket(1)
+	deleted := q.NewPriorityTicket(1)
+	q.Delete(deleted)
+
+	expects := []Ticket{high, normal1, normal2, low}
+	if q.Len() != len(expects) {
+		t.Fatalf("expected %d tickets, got %d", len(expects), q.Len())
+	}
+	for i, ticket := range expects {
+		for _, other := range expects[i+1:] {
+			if q.Next(other, func() bool { return true }) {
+				t.Fatalf("ticket %d was served before ticket %d", other, ticket)
+			}
+		}
+		if !q.Next(ticket, func() bool { return true }) {
+			t.Fatalf("ticket %d should be next", ticket)
+		}
+	}
+}
+
+func TestTicketQueue_Delete(t *testing.T) {
+	q := TicketQueue{}
+	first := q.NewTicket()
+	second := q.NewTicket()
+	third := q.NewTicket()
+	q.Delete(second)
+
+	if q.Len() != 2 {
+		t.Fatalf("expected 2 tickets, got %d", q.Len())
+	}
+	if !q.Next(first, func() bool { return true }) {
+		t.Fatal("the first ticket should be next")
+	}
+	if !q.Next(third, func() bool { return true }) {
+		t.Fatal("the third ticket should be next once the first is served")
+	}
+}
@@ -0,0 +1,362 @@
+package disgord
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrIteratorDone is returned by the Next method of an iterator once every item has been returned.
+//
+//  it := client.Guild(guildID).IterateMembers()
+//  for {
+//  	member, err := it.Next(ctx)
+//  	if errors.Is(err, disgord.ErrIteratorDone) {
+//  		break
+//  	} else if err != nil {
+//  		return err
+//  	}
+//  	// ...
+//  }
+var ErrIteratorDone = errors.New("no more items in iterator")
+
+const (
+	iterateMembersLimit   = 1000
+	iterateBansLimit      = 1000
+	iterateAuditLogsLimit = 100
+	iterateReactionsLimit = 100
+	iterateGuildsLimit    = 200
+)
+
+// pager walks a paginated list endpoint, such that only a single page is held in memory at any time.
+// The typed iterators store the page, while the pager keeps track of the position within it.
+type pager struct {
+	index int
+	size  int
+
+	// last is set once a page was shorter than the limit
+	last bool
+	err  error
+}
+
+// next returns the index of the next item in the current page, and fetches the next page when the
+// current one is exhausted. A failed fetch can be retried by calling next again, unless the error was
+// ErrIteratorDone.
+func (p *pager) next(ctx context.Context, fetch func(ctx context.Context) (size int, err error)) (int, error) {
+	if p.err != nil {
+		return -1, p.err
+	}
+	if p.index >= p.size {
+		if p.last {
+			p.err = ErrIteratorDone
+			return -1, p.err
+		}
+		size, err := fetch(ctx)
+		if err != nil {
+			return -1, err
+		}
+		p.index, p.size = 0, size
+		if size == 0 {
+			p.err = ErrIteratorDone
+			return -1, p.err
+		}
+	}
+
+	i := p.index
+	p.index++
+	return i, nil
+}
+
+// advance moves the cursor to the given snowflake, and returns false if the cursor did not move in the
+// direction of the pagination. This happens when Discord ignores the cursor and returns the same items again.
+func advance(cursor *Snowflake, id Snowflake, ascending bool) bool {
+	moved := id > *cursor
+	if !ascending {
+		moved = cursor.IsZero() || id < *cursor
+	}
+	*cursor = id
+	return moved
+}
+
+// MemberIterator returns every member of a guild, sorted by user id, in pages of 1,000 members.
+type MemberIterator struct {
+	pager
+	builder guildQueryBuilder
+	flags   []Flag
+	after   Snowflake
+	page    []*Member
+}
+
+// Next returns the next member, or ErrIteratorDone when every member has been returned.
+func (it *MemberIterator) Next(ctx context.Context) (*Member, error) {
+	i, err := it.next(ctx, it.fetch)
+	if err != nil {
+		return nil, err
+	}
+	return it.page[i], nil
+}
+
+func (it *MemberIterator) fetch(ctx context.Context) (int, error) {
+	it.builder.ctx = ctx
+	members, err := it.builder.getGuildMembers(&getGuildMembersParams{
+		After: it.after,
+		Limit: iterateMembersLimit,
+	}, it.flags...)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(members) == 0 {
+		return 0, nil
+	}
+	last := members[len(members)-1]
+	last.updateInternals()
+	if !advance(&it.after, last.UserID, true) {
+		return 0, nil
+	}
+
+	it.page = members
+	it.last = len(members) < iterateMembersLimit
+	return len(members), nil
+}
+
+// IterateMembers returns an iterator over every guild member. Unlike GetMembers, only a single page
+// of members is held in memory, which makes it suitable for walking large guilds. The members are
+// always requested from Discord.
+func (g guildQueryBuilder) IterateMembers(flags ...Flag) *MemberIterator {
+	return &MemberIterator{
+		builder: g,
+		flags:   append(append([]Flag{}, flags...), IgnoreCache),
+	}
+}
+
+// BanIterator returns every ban of a guild, sorted by user id, in pages of 1,000 bans.
+type BanIterator struct {
+	pager
+	builder guildQueryBuilder
+	flags   []Flag
+	after   Snowflake
+	page    []*Ban
+}
+
+// Next returns the next ban, or ErrIteratorDone when every ban has been returned.
+func (it *BanIterator) Next(ctx context.Context) (*Ban, error) {
+	i, err := it.next(ctx, it.fetch)
+	if err != nil {
+		return nil, err
+	}
+	return it.page[i], nil
+}
+
+func (it *BanIterator) fetch(ctx context.Context) (int, error) {
+	it.builder.ctx = ctx
+	bans, err := it.builder.getBans(&getGuildBansParams{
+		After: it.after,
+		Limit: iterateBansLimit,
+	}, it.flags...)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(bans) == 0 || bans[len(bans)-1].User == nil {
+		return 0, nil
+	}
+	if !advance(&it.after, bans[len(bans)-1].User.ID, true) {
+		return 0, nil
+	}
+
+	it.page = bans
+	it.last = len(bans) < iterateBansLimit
+	return len(bans), nil
+}
+
+// IterateBans returns an iterator over every ban in the guild. Requires the 'BAN_MEMBERS' permission.
+func (g guildQueryBuilder) IterateBans(flags ...Flag) *BanIterator {
+	return &BanIterator{
+		builder: g,
+		flags:   flags,
+	}
+}
+
+// IterateAuditLogsParams filters the audit log entries returned by an AuditLogIterator.
+type IterateAuditLogsParams struct {
+	// UserID only returns entries made by the given user.
+	UserID Snowflake
+
+	// ActionType only returns entries of the given type, unless it is 0.
+	ActionType AuditLogEvt
+
+	// Before only returns entries older than the given entry id.
+	Before Snowflake
+
+	// After only returns entries newer than the given entry id.
+	After Snowflake
+}
+
+// AuditLogIterator returns the audit log entries of a guild, newest first, in pages of 100 entries.
+type AuditLogIterator struct {
+	pager
+	builder guildQueryBuilder
+	flags   []Flag
+	params  IterateAuditLogsParams
+	page    *AuditLog
+}
+
+// Next returns the next audit log entry, or ErrIteratorDone when every entry has been returned.
+func (it *AuditLogIterator) Next(ctx context.Context) (*AuditLogEntry, error) {
+	i, err := it.next(ctx, it.fetch)
+	if err != nil {
+		return nil, err
+	}
+	return it.page.AuditLogEntries[i], nil
+}
+
+// Page returns the audit log holding the entry last returned by Next, which contains the users and
+// webhooks referenced by the entries of the page.
+func (it *AuditLogIterator) Page() *AuditLog {
+	return it.page
+}
+
+func (it *AuditLogIterator) fetch(ctx context.Context) (int, error) {
+	builder := it.builder.WithContext(ctx).GetAuditLogs(it.flags...).SetLimit(iterateAuditLogsLimit)
+	if !it.params.UserID.IsZero() {
+		builder.SetUserID(it.params.UserID)
+	}
+	if it.params.ActionType != 0 {
+		builder.SetActionType(uint(it.params.ActionType))
+	}
+	if !it.params.Before.IsZero() {
+		builder.SetBefore(it.params.Before)
+	}
+
+	log, err := builder.Execute()
+	if err != nil {
+		return 0, err
+	}
+
+	entries := log.AuditLogEntries
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	if !advance(&it.params.Before, entries[len(entries)-1].ID, false) {
+		return 0, nil
+	}
+	it.last = len(entries) < iterateAuditLogsLimit
+
+	// the pages are walked from the newest entry, so the remaining entries are older than After
+	if !it.params.After.IsZero() {
+		for i := range entries {
+			if entries[i].ID <= it.params.After {
+				entries = entries[:i]
+				it.last = true
+				break
+			}
+		}
+		log.AuditLogEntries = entries
+	}
+
+	it.page = log
+	return len(entries), nil
+}
+
+// IterateAuditLogs returns an iterator over the audit log entries of the guild, where params is optional.
+// Requires the 'VIEW_AUDIT_LOG' permission.
+func (g guildQueryBuilder) IterateAuditLogs(params *IterateAuditLogsParams, flags ...Flag) *AuditLogIterator {
+	it := &AuditLogIterator{
+		builder: g,
+		flags:   flags,
+	}
+	if params != nil {
+		it.params = *params
+	}
+	return it
+}
+
+// ReactionUserIterator returns the users that reacted with an emoji, sorted by user id, in pages of 100 users.
+type ReactionUserIterator struct {
+	pager
+	builder reactionQueryBuilder
+	flags   []Flag
+	after   Snowflake
+	page    []*User
+}
+
+// Next returns the next user, or ErrIteratorDone when every user has been returned.
+func (it *ReactionUserIterator) Next(ctx context.Context) (*User, error) {
+	i, err := it.next(ctx, it.fetch)
+	if err != nil {
+		return nil, err
+	}
+	return it.page[i], nil
+}
+
+func (it *ReactionUserIterator) fetch(ctx context.Context) (int, error) {
+	it.builder.ctx = ctx
+	users, err := it.builder.Get(&GetReactionURLParams{
+		After: it.after,
+		Limit: iterateReactionsLimit,
+	}, it.flags...)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(users) == 0 || !advance(&it.after, users[len(users)-1].ID, true) {
+		return 0, nil
+	}
+
+	it.page = users
+	it.last = len(users) < iterateReactionsLimit
+	return len(users), nil
+}
+
+// Iterate returns an iterator over every user that reacted with the emoji.
+func (r reactionQueryBuilder) Iterate(flags ...Flag) *ReactionUserIterator {
+	return &ReactionUserIterator{
+		builder: r,
+		flags:   flags,
+	}
+}
+
+// GuildIterator returns the guilds of the current user, sorted by id, in pages of 200 guilds.
+type GuildIterator struct {
+	pager
+	builder currentUserQueryBuilder
+	flags   []Flag
+	after   Snowflake
+	page    []*Guild
+}
+
+// Next returns the next guild, or ErrIteratorDone when every guild has been returned.
+func (it *GuildIterator) Next(ctx context.Context) (*Guild, error) {
+	i, err := it.next(ctx, it.fetch)
+	if err != nil {
+		return nil, err
+	}
+	return it.page[i], nil
+}
+
+func (it *GuildIterator) fetch(ctx context.Context) (int, error) {
+	it.builder.ctx = ctx
+	guilds, err := it.builder.GetGuilds(&GetCurrentUserGuildsParams{
+		After: it.after,
+		Limit: iterateGuildsLimit,
+	}, it.flags...)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(guilds) == 0 || !advance(&it.after, guilds[len(guilds)-1].ID, true) {
+		return 0, nil
+	}
+
+	it.page = guilds
+	it.last = len(guilds) < iterateGuildsLimit
+	return len(guilds), nil
+}
+
+// IterateGuilds returns an iterator over every guild the current user is a member of.
+func (c currentUserQueryBuilder) IterateGuilds(flags ...Flag) *GuildIterator {
+	return &GuildIterator{
+		builder: c,
+		flags:   flags,
+	}
+}
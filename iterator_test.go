@@ -0,0 +1,173 @@
+// +build !integration
+
+package disgord
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/andersfylling/disgord/json"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newPagingClient creates a client where every REST request is answered by the given page function.
+// A nil page is given as a 204 empty response.
+func newPagingClient(t *testing.T, page func(req *http.Request) interface{}) *Client {
+	client, err := NewClient(context.Background(), Config{
+		BotToken: "testing",
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			v := page(req)
+			if v == nil {
+				return &http.Response{
+					StatusCode: http.StatusNoContent,
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+					Request:    req,
+				}, nil
+			}
+			body, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(bytes.NewReader(body)),
+				Request:    req,
+			}, nil
+		})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func queryInt(req *http.Request, key string) int {
+	v, _ := strconv.Atoi(req.URL.Query().Get(key))
+	return v
+}
+
+func TestMemberIterator(t *testing.T) {
+	const total = 2500
+	var requests int
+	client := newPagingClient(t, func(req *http.Request) interface{} {
+		requests++
+		after, limit := queryInt(req, "after"), queryInt(req, "limit")
+		if limit != iterateMembersLimit {
+			t.Errorf("expected limit %d, got %d", iterateMembersLimit, limit)
+		}
+		members := make([]*Member, 0, limit)
+		for id := after + 1; id <= total && len(members) < limit; id++ {
+			members = append(members, &Member{User: &User{ID: Snowflake(id)}})
+		}
+		return members
+	})
+
+	ctx := context.Background()
+	it := client.Guild(1).IterateMembers()
+	for i := 1; i <= total; i++ {
+		member, err := it.Next(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if member.User.ID != Snowflake(i) {
+			t.Fatalf("expected member %d, got %d", i, member.User.ID)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := it.Next(ctx); !errors.Is(err, ErrIteratorDone) {
+			t.Fatalf("expected ErrIteratorDone, got %v", err)
+		}
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+
+	// the flags of the caller must not be overwritten
+	flags := make([]Flag, 1, 2)
+	flags[0] = PriorityLow
+	client.Guild(1).IterateMembers(flags...)
+	if extra := flags[:2][1]; extra != 0 {
+		t.Errorf("expected the flags of the caller to be untouched, got %v", extra)
+	}
+}
+
+func TestAuditLogIterator(t *testing.T) {
+	const total = 150
+	var requests int
+	client := newPagingClient(t, func(req *http.Request) interface{} {
+		requests++
+		if userID := req.URL.Query().Get("user_id"); userID != "7" {
+			t.Errorf("expected user_id 7, got %q", userID)
+		}
+		before, limit := queryInt(req, "before"), queryInt(req, "limit")
+		if before == 0 {
+			before = total + 1
+		}
+		log := &AuditLog{}
+		for id := before - 1; id > 0 && len(log.AuditLogEntries) < limit; id-- {
+			log.AuditLogEntries = append(log.AuditLogEntries, &AuditLogEntry{ID: Snowflake(id)})
+		}
+		return log
+	})
+
+	ctx := context.Background()
+	it := client.Guild(1).IterateAuditLogs(&IterateAuditLogsParams{UserID: 7})
+	for i := total; i > 0; i-- {
+		entry, err := it.Next(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry.ID != Snowflake(i) {
+			t.Fatalf("expected entry %d, got %d", i, entry.ID)
+		}
+	}
+	if _, err := it.Next(ctx); !errors.Is(err, ErrIteratorDone) {
+		t.Fatalf("expected ErrIteratorDone, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestGuildIterator_ignoredCursor(t *testing.T) {
+	var requests int
+	client := newPagingClient(t, func(req *http.Request) interface{} {
+		requests++
+		guilds := make([]*Guild, iterateGuildsLimit)
+		for i := range guilds {
+			guilds[i] = &Guild{ID: Snowflake(i + 1)}
+		}
+		return guilds
+	})
+
+	ctx := context.Background()
+	it := client.CurrentUser().IterateGuilds()
+	var count int
+	for {
+		_, err := it.Next(ctx)
+		if errors.Is(err, ErrIteratorDone) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		count++
+	}
+	if count != iterateGuildsLimit {
+		t.Errorf("expected %d guilds, got %d", iterateGuildsLimit, count)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
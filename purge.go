@@ -0,0 +1,167 @@
+package disgord
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// bulkDeleteMaxAge is the age at which Discord rejects messages in a bulk delete. A minute is subtracted to
+// account for clock differences and the time spent waiting for the rate limits.
+const bulkDeleteMaxAge = 14*24*time.Hour - time.Minute
+
+// PurgeParams decides which messages are deleted by a purge. Every set filter must match for a message to be deleted.
+type PurgeParams struct {
+	// Count is the maximum number of messages to delete. Every matching message is deleted when Count is 0.
+	Count int
+
+	// AuthorID only deletes messages sent by the given user.
+	AuthorID Snowflake
+
+	// Content only deletes messages where the content matches the regular expression.
+	Content *regexp.Regexp
+
+	// HasAttachments only deletes messages with at least one attachment.
+	HasAttachments bool
+
+	// Since and Until only deletes messages sent within the time window, where a zero time leaves
+	// that end of the window open. The history is read from Until, or the latest message, towards Since.
+	Since time.Time
+	Until time.Time
+
+	// Filter is an optional predicate for anything not covered by the other filters.
+	Filter func(msg *Message) bool
+
+	// BulkOnly stops the purge at the first message that is too old to be bulk deleted, instead of
+	// deleting old messages one by one. Single deletes are heavily rate limited, so a purge of old
+	// messages can take a long time.
+	BulkOnly bool
+
+	// OnProgress is called after every page of history and every delete request.
+	OnProgress func(progress *PurgeProgress)
+}
+
+// PurgeProgress reports the state of a purge.
+type PurgeProgress struct {
+	// Scanned is the number of messages read from the channel history.
+	Scanned int
+
+	// Deleted is the number of messages deleted.
+	Deleted int
+}
+
+func (p *PurgeParams) matches(msg *Message) bool {
+	if !p.AuthorID.IsZero() && (msg.Author == nil || msg.Author.ID != p.AuthorID) {
+		return false
+	}
+	if p.Content != nil && !p.Content.MatchString(msg.Content) {
+		return false
+	}
+	if p.HasAttachments && len(msg.Attachments) == 0 {
+		return false
+	}
+	if p.Filter != nil && !p.Filter(msg) {
+		return false
+	}
+	return true
+}
+
+// Purge deletes the messages matching the params, by reading the channel history from the newest message.
+// Messages younger than 14 days are bulk deleted in chunks of up to 100 messages, while older messages
+// are deleted one by one unless params.BulkOnly is set. Requires the 'MANAGE_MESSAGES' permission.
+//
+// The progress is returned on error as well, as some messages might have been deleted.
+//
+//  progress, err := client.Channel(channelID).Purge(&disgord.PurgeParams{
+//  	Count:    50,
+//  	AuthorID: spammerID,
+//  })
+func (c channelQueryBuilder) Purge(params *PurgeParams, flags ...Flag) (*PurgeProgress, error) {
+	if c.cid.IsZero() {
+		return nil, errors.New("channelID must be set to purge channel messages")
+	}
+	if params == nil {
+		params = &PurgeParams{}
+	}
+	if params.Count < 0 {
+		return nil, errors.New("count can not be negative")
+	}
+
+	progress := &PurgeProgress{}
+	report := func() {
+		if params.OnProgress != nil {
+			params.OnProgress(progress)
+		}
+	}
+	remaining := func() int {
+		return params.Count - progress.Deleted
+	}
+
+	filter := &GetMessagesParams{Limit: 100}
+	if !params.Until.IsZero() {
+		filter.Before = snowflakeAt(params.Until)
+	}
+
+	for params.Count == 0 || remaining() > 0 {
+		messages, err := c.getMessages(filter, flags...)
+		if err != nil {
+			return progress, err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		progress.Scanned += len(messages)
+
+		// messages are given newest first
+		var bulk, single []Snowflake
+		var done bool
+		for _, msg := range messages {
+			old := time.Since(msg.ID.Date()) >= bulkDeleteMaxAge
+			if (!params.Since.IsZero() && msg.ID.Date().Before(params.Since)) || (old && params.BulkOnly) {
+				done = true
+				break
+			}
+			if !params.matches(msg) {
+				continue
+			}
+			if params.Count > 0 && len(bulk)+len(single) >= remaining() {
+				done = true
+				break
+			}
+
+			if old {
+				single = append(single, msg.ID)
+			} else {
+				bulk = append(bulk, msg.ID)
+			}
+		}
+		report()
+
+		// a bulk delete requires at least two messages
+		if len(bulk) == 1 {
+			single = append(bulk, single...)
+			bulk = nil
+		}
+		if len(bulk) > 0 {
+			if err = c.DeleteMessages(&DeleteMessagesParams{Messages: bulk}, flags...); err != nil {
+				return progress, err
+			}
+			progress.Deleted += len(bulk)
+			report()
+		}
+		for _, id := range single {
+			if err = c.Message(id).WithContext(c.ctx).Delete(flags...); err != nil {
+				return progress, err
+			}
+			progress.Deleted++
+			report()
+		}
+
+		if done || len(messages) < int(filter.Limit) {
+			break
+		}
+		filter.Before = messages[len(messages)-1].ID
+	}
+
+	return progress, nil
+}
@@ -0,0 +1,117 @@
+// +build !integration
+
+package disgord
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord/json"
+)
+
+func TestChannelQueryBuilder_Purge(t *testing.T) {
+	// 150 messages sent a minute apart, followed by 50 messages older than 14 days. Every other message
+	// is sent by the author being purged.
+	now := time.Now()
+	var history []*Message
+	for i := 0; i < 200; i++ {
+		sent := now.Add(-time.Duration(i+1) * time.Minute)
+		if i >= 150 {
+			sent = now.Add(-15*24*time.Hour - time.Duration(i)*time.Minute)
+		}
+		history = append(history, &Message{
+			ID:     snowflakeAt(sent),
+			Author: &User{ID: Snowflake(1 + i%2)},
+		})
+	}
+
+	var bulkDeleted, singleDeleted []Snowflake
+	client := newPagingClient(t, func(req *http.Request) interface{} {
+		switch {
+		case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/bulk-delete"):
+			body, _ := ioutil.ReadAll(req.Body)
+			params := &DeleteMessagesParams{}
+			if err := json.Unmarshal(body, params); err != nil {
+				t.Fatal(err)
+			}
+			if len(params.Messages) < 2 || len(params.Messages) > 100 {
+				t.Errorf("bulk deleted %d messages", len(params.Messages))
+			}
+			bulkDeleted = append(bulkDeleted, params.Messages...)
+			return nil
+		case req.Method == http.MethodDelete:
+			id := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+			singleDeleted = append(singleDeleted, ParseSnowflakeString(id))
+			return nil
+		}
+
+		before := Snowflake(queryInt(req, "before"))
+		var page []*Message
+		for _, msg := range history {
+			if (before.IsZero() || msg.ID < before) && len(page) < queryInt(req, "limit") {
+				page = append(page, msg)
+			}
+		}
+		return page
+	})
+
+	t.Run("bulk", func(t *testing.T) {
+		bulkDeleted, singleDeleted = nil, nil
+		var reports int
+		progress, err := client.Channel(1).Purge(&PurgeParams{
+			Count:    60,
+			AuthorID: 1,
+			OnProgress: func(_ *PurgeProgress) {
+				reports++
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if progress.Deleted != 60 || len(bulkDeleted) != 60 || len(singleDeleted) != 0 {
+			t.Errorf("expected 60 bulk deletes, got %+v, %d bulk and %d single deletes", progress, len(bulkDeleted), len(singleDeleted))
+		}
+		if progress.Scanned != 200 {
+			t.Errorf("expected 200 scanned messages, got %d", progress.Scanned)
+		}
+		if reports != 4 {
+			t.Errorf("expected 4 progress reports, got %d", reports)
+		}
+	})
+	t.Run("old", func(t *testing.T) {
+		bulkDeleted, singleDeleted = nil, nil
+		progress, err := client.Channel(1).Purge(&PurgeParams{AuthorID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if progress.Deleted != 100 || len(bulkDeleted) != 75 || len(singleDeleted) != 25 {
+			t.Errorf("expected 75 bulk and 25 single deletes, got %d bulk and %d single deletes", len(bulkDeleted), len(singleDeleted))
+		}
+	})
+	t.Run("bulk-only", func(t *testing.T) {
+		bulkDeleted, singleDeleted = nil, nil
+		progress, err := client.Channel(1).Purge(&PurgeParams{BulkOnly: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if progress.Deleted != 150 || len(singleDeleted) != 0 {
+			t.Errorf("expected 150 bulk deletes, got %+v with %d single deletes", progress, len(singleDeleted))
+		}
+	})
+	t.Run("time-window", func(t *testing.T) {
+		bulkDeleted, singleDeleted = nil, nil
+		progress, err := client.Channel(1).Purge(&PurgeParams{
+			Since: now.Add(-30*time.Minute - time.Second),
+			Until: now.Add(-10*time.Minute + time.Second),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if progress.Deleted != 21 {
+			t.Errorf("expected 21 deletes, got %d", progress.Deleted)
+		}
+	})
+}
@@ -58,6 +58,9 @@ type ReactionQueryBuilder interface {
 	// GetReaction Get a list of Users that reacted with this emoji. Returns an array of user objects on success.
 	Get(params URLQueryStringer, flags ...Flag) (reactors []*User, err error)
 
+	// Iterate returns an iterator over every user that reacted with the emoji.
+	Iterate(flags ...Flag) *ReactionUserIterator
+
 	// DeleteOwnReaction Delete a reaction the current user has made for the message.
 	// Returns a 204 empty response on success.
 	DeleteOwn(flags ...Flag) (err error)
@@ -3,10 +3,12 @@ package disgord
 import (
 	"errors"
 	"fmt"
+	"runtime/debug"
 	"sync"
 	"time"
 
 	"github.com/andersfylling/disgord/internal/gateway"
+	"github.com/andersfylling/disgord/json"
 )
 
 //////////////////////////////////////////////////////
@@ -43,6 +45,19 @@ func (c *Client) demultiplexer(d *dispatcher, read <-chan *gateway.Event) {
 		// 	continue // move on to next event
 		// }
 
+		// events created by Disgord does not affect the cache
+		if isDisgordEvent(evt.Name) {
+			resource := defineResource(evt.Name)
+			if err := json.Unmarshal(evt.Data, resource); err != nil {
+				d.session.Logger().Error(fmt.Errorf("demultiplexer{%s}: %w", evt.Name, err))
+				continue
+			}
+			resource.setShardID(evt.ShardID)
+
+			c.dispatch(d, evt, resource)
+			continue
+		}
+
 		resourceI, err := cacheDispatcher(c.cache, evt.Name, evt.Data)
 		if resourceI == nil {
 			err = fmt.Errorf("cache did not instantiate object. Prev error: %w", err)
@@ -67,8 +82,17 @@ func (c *Client) demultiplexer(d *dispatcher, read <-chan *gateway.Event) {
 		resource := resourceI.(evtResource)
 		resource.setShardID(evt.ShardID)
 
+		c.dispatch(d, evt, resource)
+	}
+}
+
+// dispatch passes the event to the handlers, in order when an ordered dispatch mode is configured.
+func (c *Client) dispatch(d *dispatcher, evt *gateway.Event, resource resource) {
+	if c.dispatchPool == nil {
 		go d.dispatch(evt.Name, resource)
+		return
 	}
+	c.dispatchPool.dispatch(evt, resource)
 }
 
 //////////////////////////////////////////////////////
@@ -88,6 +112,8 @@ type dispatcher struct {
 	// use session to allow mocking the Client instance later on
 	session  Session
 	shutdown chan struct{}
+
+	onHandlerError func(err *HandlerError)
 }
 
 func (d *dispatcher) addSessionInstance(s Session) {
@@ -138,14 +164,14 @@ func (d *dispatcher) dispatch(evtName string, evt resource) {
 		//}
 		spec.Lock()
 		if dead := spec.ctrl.IsDead(); !dead {
-			localEvt := spec.runMdlws(evt)
+			localEvt := d.runMdlwsSafely(evtName, evt, spec)
 			if localEvt == nil {
 				spec.Unlock()
 				continue
 			}
 
 			for _, handler := range spec.handlers {
-				d.trigger(handler, localEvt)
+				d.triggerSafely(evtName, evt, handler, localEvt)
 			}
 
 			spec.ctrl.Update()
@@ -192,6 +218,89 @@ func (d *dispatcher) dispatch(evtName string, evt resource) {
 	}(dead)
 }
 
+// triggerSafely runs a handler, and reports the error it returned or the panic it caused. The shard ID is
+// taken from the event as it was received, as middlewares can replace the event.
+func (d *dispatcher) triggerSafely(evtName string, received resource, h Handler, evt resource) {
+	shardID := shardIDOf(received)
+	defer func() {
+		if r := recover(); r != nil {
+			d.handlerFailed(&HandlerError{
+				Event:   evtName,
+				ShardID: shardID,
+				Err:     fmt.Errorf("handler panicked: %v", r),
+				Panic:   r,
+				Stack:   debug.Stack(),
+			})
+		}
+	}()
+
+	if err := d.trigger(h, evt); err != nil {
+		d.handlerFailed(&HandlerError{
+			Event:   evtName,
+			ShardID: shardID,
+			Err:     err,
+		})
+	}
+}
+
+// runMdlwsSafely runs the middlewares of the spec. A panicking middleware is reported like a panicking handler,
+// and the handlers of the spec are skipped.
+func (d *dispatcher) runMdlwsSafely(evtName string, received resource, spec *handlerSpec) (evt interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			evt = nil
+			d.handlerFailed(&HandlerError{
+				Event:   evtName,
+				ShardID: shardIDOf(received),
+				Err:     fmt.Errorf("middleware panicked: %v", r),
+				Panic:   r,
+				Stack:   debug.Stack(),
+			})
+		}
+	}()
+	return spec.runMdlws(received)
+}
+
+func shardIDOf(received resource) uint {
+	if withShard, ok := received.(evtResource); ok {
+		return withShard.getShardID()
+	}
+	return 0
+}
+
+func (d *dispatcher) handlerFailed(err *HandlerError) {
+	d.session.Logger().Error(err)
+	if d.onHandlerError != nil {
+		d.onHandlerError(err)
+	}
+}
+
+// HandlerError is reported when a handler returned an error, or a handler or middleware panicked, see
+// Config.OnHandlerError.
+type HandlerError struct {
+	Event   string
+	ShardID uint
+
+	// Err is the error returned by the handler, or describes the panic.
+	Err error
+
+	// Panic is the recovered value and Stack the stack trace of the goroutine, when the handler or middleware
+	// panicked.
+	Panic interface{}
+	Stack []byte
+}
+
+func (e *HandlerError) Error() string {
+	if e.Panic != nil {
+		return fmt.Sprintf("%s handler on shard %d: %s\n%s", e.Event, e.ShardID, e.Err, e.Stack)
+	}
+	return fmt.Sprintf("%s handler on shard %d: %s", e.Event, e.ShardID, e.Err)
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
 //////////////////////////////////////////////////////
 //
 // Handler logic
@@ -76,12 +76,24 @@ func defineResource(evt string) (resource evtResource) {
 		resource = &Ready{}
 	case EvtResumed:
 		resource = &Resumed{}
+	case EvtShardConnected:
+		resource = &ShardConnected{}
+	case EvtShardDisconnected:
+		resource = &ShardDisconnected{}
+	case EvtShardResumed:
+		resource = &ShardResumed{}
+	case EvtShardScaled:
+		resource = &ShardScaled{}
 	case EvtTypingStart:
 		resource = &TypingStart{}
 	case EvtUserUpdate:
 		resource = &UserUpdate{}
+	case EvtVoiceClientDisconnect:
+		resource = &VoiceClientDisconnect{}
 	case EvtVoiceServerUpdate:
 		resource = &VoiceServerUpdate{}
+	case EvtVoiceSpeaking:
+		resource = &VoiceSpeaking{}
 	case EvtVoiceStateUpdate:
 		resource = &VoiceStateUpdate{}
 	case EvtWebhooksUpdate:
@@ -91,6 +103,26 @@ func defineResource(evt string) (resource evtResource) {
 	return resource
 }
 
+// isDisgordEvent checks if the event is emitted by Disgord rather than Discord. Such events
+// are not processed by the cache.
+func isDisgordEvent(evt string) bool {
+	switch evt {
+	case EvtShardConnected:
+		return true
+	case EvtShardDisconnected:
+		return true
+	case EvtShardResumed:
+		return true
+	case EvtShardScaled:
+		return true
+	case EvtVoiceClientDisconnect:
+		return true
+	case EvtVoiceSpeaking:
+		return true
+	}
+	return false
+}
+
 func isHandler(h Handler) (ok bool) {
 	switch h.(type) {
 	case HandlerSimple:
@@ -101,150 +133,260 @@ func isHandler(h Handler) (ok bool) {
 		ok = true
 	case HandlerChannelCreate:
 		ok = true
+	case HandlerChannelCreateErr:
+		ok = true
 	case chan *ChannelCreate:
 		ok = true
 	case HandlerChannelDelete:
 		ok = true
+	case HandlerChannelDeleteErr:
+		ok = true
 	case chan *ChannelDelete:
 		ok = true
 	case HandlerChannelPinsUpdate:
 		ok = true
+	case HandlerChannelPinsUpdateErr:
+		ok = true
 	case chan *ChannelPinsUpdate:
 		ok = true
 	case HandlerChannelUpdate:
 		ok = true
+	case HandlerChannelUpdateErr:
+		ok = true
 	case chan *ChannelUpdate:
 		ok = true
 	case HandlerGuildBanAdd:
 		ok = true
+	case HandlerGuildBanAddErr:
+		ok = true
 	case chan *GuildBanAdd:
 		ok = true
 	case HandlerGuildBanRemove:
 		ok = true
+	case HandlerGuildBanRemoveErr:
+		ok = true
 	case chan *GuildBanRemove:
 		ok = true
 	case HandlerGuildCreate:
 		ok = true
+	case HandlerGuildCreateErr:
+		ok = true
 	case chan *GuildCreate:
 		ok = true
 	case HandlerGuildDelete:
 		ok = true
+	case HandlerGuildDeleteErr:
+		ok = true
 	case chan *GuildDelete:
 		ok = true
 	case HandlerGuildEmojisUpdate:
 		ok = true
+	case HandlerGuildEmojisUpdateErr:
+		ok = true
 	case chan *GuildEmojisUpdate:
 		ok = true
 	case HandlerGuildIntegrationsUpdate:
 		ok = true
+	case HandlerGuildIntegrationsUpdateErr:
+		ok = true
 	case chan *GuildIntegrationsUpdate:
 		ok = true
 	case HandlerGuildMemberAdd:
 		ok = true
+	case HandlerGuildMemberAddErr:
+		ok = true
 	case chan *GuildMemberAdd:
 		ok = true
 	case HandlerGuildMemberRemove:
 		ok = true
+	case HandlerGuildMemberRemoveErr:
+		ok = true
 	case chan *GuildMemberRemove:
 		ok = true
 	case HandlerGuildMemberUpdate:
 		ok = true
+	case HandlerGuildMemberUpdateErr:
+		ok = true
 	case chan *GuildMemberUpdate:
 		ok = true
 	case HandlerGuildMembersChunk:
 		ok = true
+	case HandlerGuildMembersChunkErr:
+		ok = true
 	case chan *GuildMembersChunk:
 		ok = true
 	case HandlerGuildRoleCreate:
 		ok = true
+	case HandlerGuildRoleCreateErr:
+		ok = true
 	case chan *GuildRoleCreate:
 		ok = true
 	case HandlerGuildRoleDelete:
 		ok = true
+	case HandlerGuildRoleDeleteErr:
+		ok = true
 	case chan *GuildRoleDelete:
 		ok = true
 	case HandlerGuildRoleUpdate:
 		ok = true
+	case HandlerGuildRoleUpdateErr:
+		ok = true
 	case chan *GuildRoleUpdate:
 		ok = true
 	case HandlerGuildUpdate:
 		ok = true
+	case HandlerGuildUpdateErr:
+		ok = true
 	case chan *GuildUpdate:
 		ok = true
 	case HandlerInteractionCreate:
 		ok = true
+	case HandlerInteractionCreateErr:
+		ok = true
 	case chan *InteractionCreate:
 		ok = true
 	case HandlerInviteCreate:
 		ok = true
+	case HandlerInviteCreateErr:
+		ok = true
 	case chan *InviteCreate:
 		ok = true
 	case HandlerInviteDelete:
 		ok = true
+	case HandlerInviteDeleteErr:
+		ok = true
 	case chan *InviteDelete:
 		ok = true
 	case HandlerMessageCreate:
 		ok = true
+	case HandlerMessageCreateErr:
+		ok = true
 	case chan *MessageCreate:
 		ok = true
 	case HandlerMessageDelete:
 		ok = true
+	case HandlerMessageDeleteErr:
+		ok = true
 	case chan *MessageDelete:
 		ok = true
 	case HandlerMessageDeleteBulk:
 		ok = true
+	case HandlerMessageDeleteBulkErr:
+		ok = true
 	case chan *MessageDeleteBulk:
 		ok = true
 	case HandlerMessageReactionAdd:
 		ok = true
+	case HandlerMessageReactionAddErr:
+		ok = true
 	case chan *MessageReactionAdd:
 		ok = true
 	case HandlerMessageReactionRemove:
 		ok = true
+	case HandlerMessageReactionRemoveErr:
+		ok = true
 	case chan *MessageReactionRemove:
 		ok = true
 	case HandlerMessageReactionRemoveAll:
 		ok = true
+	case HandlerMessageReactionRemoveAllErr:
+		ok = true
 	case chan *MessageReactionRemoveAll:
 		ok = true
 	case HandlerMessageReactionRemoveEmoji:
 		ok = true
+	case HandlerMessageReactionRemoveEmojiErr:
+		ok = true
 	case chan *MessageReactionRemoveEmoji:
 		ok = true
 	case HandlerMessageUpdate:
 		ok = true
+	case HandlerMessageUpdateErr:
+		ok = true
 	case chan *MessageUpdate:
 		ok = true
 	case HandlerPresenceUpdate:
 		ok = true
+	case HandlerPresenceUpdateErr:
+		ok = true
 	case chan *PresenceUpdate:
 		ok = true
 	case HandlerReady:
 		ok = true
+	case HandlerReadyErr:
+		ok = true
 	case chan *Ready:
 		ok = true
 	case HandlerResumed:
 		ok = true
+	case HandlerResumedErr:
+		ok = true
 	case chan *Resumed:
 		ok = true
+	case HandlerShardConnected:
+		ok = true
+	case HandlerShardConnectedErr:
+		ok = true
+	case chan *ShardConnected:
+		ok = true
+	case HandlerShardDisconnected:
+		ok = true
+	case HandlerShardDisconnectedErr:
+		ok = true
+	case chan *ShardDisconnected:
+		ok = true
+	case HandlerShardResumed:
+		ok = true
+	case HandlerShardResumedErr:
+		ok = true
+	case chan *ShardResumed:
+		ok = true
+	case HandlerShardScaled:
+		ok = true
+	case HandlerShardScaledErr:
+		ok = true
+	case chan *ShardScaled:
+		ok = true
 	case HandlerTypingStart:
 		ok = true
+	case HandlerTypingStartErr:
+		ok = true
 	case chan *TypingStart:
 		ok = true
 	case HandlerUserUpdate:
 		ok = true
+	case HandlerUserUpdateErr:
+		ok = true
 	case chan *UserUpdate:
 		ok = true
+	case HandlerVoiceClientDisconnect:
+		ok = true
+	case HandlerVoiceClientDisconnectErr:
+		ok = true
+	case chan *VoiceClientDisconnect:
+		ok = true
 	case HandlerVoiceServerUpdate:
 		ok = true
+	case HandlerVoiceServerUpdateErr:
+		ok = true
 	case chan *VoiceServerUpdate:
 		ok = true
+	case HandlerVoiceSpeaking:
+		ok = true
+	case HandlerVoiceSpeakingErr:
+		ok = true
+	case chan *VoiceSpeaking:
+		ok = true
 	case HandlerVoiceStateUpdate:
 		ok = true
+	case HandlerVoiceStateUpdateErr:
+		ok = true
 	case chan *VoiceStateUpdate:
 		ok = true
 	case HandlerWebhooksUpdate:
 		ok = true
+	case HandlerWebhooksUpdateErr:
+		ok = true
 	case chan *WebhooksUpdate:
 		ok = true
 	}
@@ -319,12 +461,24 @@ func closeChannel(channel interface{}) {
 		close(t)
 	case chan *Resumed:
 		close(t)
+	case chan *ShardConnected:
+		close(t)
+	case chan *ShardDisconnected:
+		close(t)
+	case chan *ShardResumed:
+		close(t)
+	case chan *ShardScaled:
+		close(t)
 	case chan *TypingStart:
 		close(t)
 	case chan *UserUpdate:
 		close(t)
+	case chan *VoiceClientDisconnect:
+		close(t)
 	case chan *VoiceServerUpdate:
 		close(t)
+	case chan *VoiceSpeaking:
+		close(t)
 	case chan *VoiceStateUpdate:
 		close(t)
 	case chan *WebhooksUpdate:
@@ -349,7 +503,8 @@ func newDispatcher() *dispatcher {
 	return d
 }
 
-func (d *dispatcher) trigger(h Handler, evt resource) {
+// trigger runs the handler, and returns the error of handlers that can fail.
+func (d *dispatcher) trigger(h Handler, evt resource) error {
 	switch t := h.(type) {
 	case HandlerSimple:
 		t(d.session)
@@ -361,227 +516,350 @@ func (d *dispatcher) trigger(h Handler, evt resource) {
 		t <- evt
 	case HandlerChannelCreate:
 		t(d.session, evt.(*ChannelCreate))
+	case HandlerChannelCreateErr:
+		return t(d.session, evt.(*ChannelCreate))
 	case chan *ChannelCreate:
 		t <- evt.(*ChannelCreate)
 	case chan<- *ChannelCreate:
 		t <- evt.(*ChannelCreate)
 	case HandlerChannelDelete:
 		t(d.session, evt.(*ChannelDelete))
+	case HandlerChannelDeleteErr:
+		return t(d.session, evt.(*ChannelDelete))
 	case chan *ChannelDelete:
 		t <- evt.(*ChannelDelete)
 	case chan<- *ChannelDelete:
 		t <- evt.(*ChannelDelete)
 	case HandlerChannelPinsUpdate:
 		t(d.session, evt.(*ChannelPinsUpdate))
+	case HandlerChannelPinsUpdateErr:
+		return t(d.session, evt.(*ChannelPinsUpdate))
 	case chan *ChannelPinsUpdate:
 		t <- evt.(*ChannelPinsUpdate)
 	case chan<- *ChannelPinsUpdate:
 		t <- evt.(*ChannelPinsUpdate)
 	case HandlerChannelUpdate:
 		t(d.session, evt.(*ChannelUpdate))
+	case HandlerChannelUpdateErr:
+		return t(d.session, evt.(*ChannelUpdate))
 	case chan *ChannelUpdate:
 		t <- evt.(*ChannelUpdate)
 	case chan<- *ChannelUpdate:
 		t <- evt.(*ChannelUpdate)
 	case HandlerGuildBanAdd:
 		t(d.session, evt.(*GuildBanAdd))
+	case HandlerGuildBanAddErr:
+		return t(d.session, evt.(*GuildBanAdd))
 	case chan *GuildBanAdd:
 		t <- evt.(*GuildBanAdd)
 	case chan<- *GuildBanAdd:
 		t <- evt.(*GuildBanAdd)
 	case HandlerGuildBanRemove:
 		t(d.session, evt.(*GuildBanRemove))
+	case HandlerGuildBanRemoveErr:
+		return t(d.session, evt.(*GuildBanRemove))
 	case chan *GuildBanRemove:
 		t <- evt.(*GuildBanRemove)
 	case chan<- *GuildBanRemove:
 		t <- evt.(*GuildBanRemove)
 	case HandlerGuildCreate:
 		t(d.session, evt.(*GuildCreate))
+	case HandlerGuildCreateErr:
+		return t(d.session, evt.(*GuildCreate))
 	case chan *GuildCreate:
 		t <- evt.(*GuildCreate)
 	case chan<- *GuildCreate:
 		t <- evt.(*GuildCreate)
 	case HandlerGuildDelete:
 		t(d.session, evt.(*GuildDelete))
+	case HandlerGuildDeleteErr:
+		return t(d.session, evt.(*GuildDelete))
 	case chan *GuildDelete:
 		t <- evt.(*GuildDelete)
 	case chan<- *GuildDelete:
 		t <- evt.(*GuildDelete)
 	case HandlerGuildEmojisUpdate:
 		t(d.session, evt.(*GuildEmojisUpdate))
+	case HandlerGuildEmojisUpdateErr:
+		return t(d.session, evt.(*GuildEmojisUpdate))
 	case chan *GuildEmojisUpdate:
 		t <- evt.(*GuildEmojisUpdate)
 	case chan<- *GuildEmojisUpdate:
 		t <- evt.(*GuildEmojisUpdate)
 	case HandlerGuildIntegrationsUpdate:
 		t(d.session, evt.(*GuildIntegrationsUpdate))
+	case HandlerGuildIntegrationsUpdateErr:
+		return t(d.session, evt.(*GuildIntegrationsUpdate))
 	case chan *GuildIntegrationsUpdate:
 		t <- evt.(*GuildIntegrationsUpdate)
 	case chan<- *GuildIntegrationsUpdate:
 		t <- evt.(*GuildIntegrationsUpdate)
 	case HandlerGuildMemberAdd:
 		t(d.session, evt.(*GuildMemberAdd))
+	case HandlerGuildMemberAddErr:
+		return t(d.session, evt.(*GuildMemberAdd))
 	case chan *GuildMemberAdd:
 		t <- evt.(*GuildMemberAdd)
 	case chan<- *GuildMemberAdd:
 		t <- evt.(*GuildMemberAdd)
 	case HandlerGuildMemberRemove:
 		t(d.session, evt.(*GuildMemberRemove))
+	case HandlerGuildMemberRemoveErr:
+		return t(d.session, evt.(*GuildMemberRemove))
 	case chan *GuildMemberRemove:
 		t <- evt.(*GuildMemberRemove)
 	case chan<- *GuildMemberRemove:
 		t <- evt.(*GuildMemberRemove)
 	case HandlerGuildMemberUpdate:
 		t(d.session, evt.(*GuildMemberUpdate))
+	case HandlerGuildMemberUpdateErr:
+		return t(d.session, evt.(*GuildMemberUpdate))
 	case chan *GuildMemberUpdate:
 		t <- evt.(*GuildMemberUpdate)
 	case chan<- *GuildMemberUpdate:
 		t <- evt.(*GuildMemberUpdate)
 	case HandlerGuildMembersChunk:
 		t(d.session, evt.(*GuildMembersChunk))
+	case HandlerGuildMembersChunkErr:
+		return t(d.session, evt.(*GuildMembersChunk))
 	case chan *GuildMembersChunk:
 		t <- evt.(*GuildMembersChunk)
 	case chan<- *GuildMembersChunk:
 		t <- evt.(*GuildMembersChunk)
 	case HandlerGuildRoleCreate:
 		t(d.session, evt.(*GuildRoleCreate))
+	case HandlerGuildRoleCreateErr:
+		return t(d.session, evt.(*GuildRoleCreate))
 	case chan *GuildRoleCreate:
 		t <- evt.(*GuildRoleCreate)
 	case chan<- *GuildRoleCreate:
 		t <- evt.(*GuildRoleCreate)
 	case HandlerGuildRoleDelete:
 		t(d.session, evt.(*GuildRoleDelete))
+	case HandlerGuildRoleDeleteErr:
+		return t(d.session, evt.(*GuildRoleDelete))
 	case chan *GuildRoleDelete:
 		t <- evt.(*GuildRoleDelete)
 	case chan<- *GuildRoleDelete:
 		t <- evt.(*GuildRoleDelete)
 	case HandlerGuildRoleUpdate:
 		t(d.session, evt.(*GuildRoleUpdate))
+	case HandlerGuildRoleUpdateErr:
+		return t(d.session, evt.(*GuildRoleUpdate))
 	case chan *GuildRoleUpdate:
 		t <- evt.(*GuildRoleUpdate)
 	case chan<- *GuildRoleUpdate:
 		t <- evt.(*GuildRoleUpdate)
 	case HandlerGuildUpdate:
 		t(d.session, evt.(*GuildUpdate))
+	case HandlerGuildUpdateErr:
+		return t(d.session, evt.(*GuildUpdate))
 	case chan *GuildUpdate:
 		t <- evt.(*GuildUpdate)
 	case chan<- *GuildUpdate:
 		t <- evt.(*GuildUpdate)
 	case HandlerInteractionCreate:
 		t(d.session, evt.(*InteractionCreate))
+	case HandlerInteractionCreateErr:
+		return t(d.session, evt.(*InteractionCreate))
 	case chan *InteractionCreate:
 		t <- evt.(*InteractionCreate)
 	case chan<- *InteractionCreate:
 		t <- evt.(*InteractionCreate)
 	case HandlerInviteCreate:
 		t(d.session, evt.(*InviteCreate))
+	case HandlerInviteCreateErr:
+		return t(d.session, evt.(*InviteCreate))
 	case chan *InviteCreate:
 		t <- evt.(*InviteCreate)
 	case chan<- *InviteCreate:
 		t <- evt.(*InviteCreate)
 	case HandlerInviteDelete:
 		t(d.session, evt.(*InviteDelete))
+	case HandlerInviteDeleteErr:
+		return t(d.session, evt.(*InviteDelete))
 	case chan *InviteDelete:
 		t <- evt.(*InviteDelete)
 	case chan<- *InviteDelete:
 		t <- evt.(*InviteDelete)
 	case HandlerMessageCreate:
 		t(d.session, evt.(*MessageCreate))
+	case HandlerMessageCreateErr:
+		return t(d.session, evt.(*MessageCreate))
 	case chan *MessageCreate:
 		t <- evt.(*MessageCreate)
 	case chan<- *MessageCreate:
 		t <- evt.(*MessageCreate)
 	case HandlerMessageDelete:
 		t(d.session, evt.(*MessageDelete))
+	case HandlerMessageDeleteErr:
+		return t(d.session, evt.(*MessageDelete))
 	case chan *MessageDelete:
 		t <- evt.(*MessageDelete)
 	case chan<- *MessageDelete:
 		t <- evt.(*MessageDelete)
 	case HandlerMessageDeleteBulk:
 		t(d.session, evt.(*MessageDeleteBulk))
+	case HandlerMessageDeleteBulkErr:
+		return t(d.session, evt.(*MessageDeleteBulk))
 	case chan *MessageDeleteBulk:
 		t <- evt.(*MessageDeleteBulk)
 	case chan<- *MessageDeleteBulk:
 		t <- evt.(*MessageDeleteBulk)
 	case HandlerMessageReactionAdd:
 		t(d.session, evt.(*MessageReactionAdd))
+	case HandlerMessageReactionAddErr:
+		return t(d.session, evt.(*MessageReactionAdd))
 	case chan *MessageReactionAdd:
 		t <- evt.(*MessageReactionAdd)
 	case chan<- *MessageReactionAdd:
 		t <- evt.(*MessageReactionAdd)
 	case HandlerMessageReactionRemove:
 		t(d.session, evt.(*MessageReactionRemove))
+	case HandlerMessageReactionRemoveErr:
+		return t(d.session, evt.(*MessageReactionRemove))
 	case chan *MessageReactionRemove:
 		t <- evt.(*MessageReactionRemove)
 	case chan<- *MessageReactionRemove:
 		t <- evt.(*MessageReactionRemove)
 	case HandlerMessageReactionRemoveAll:
 		t(d.session, evt.(*MessageReactionRemoveAll))
+	case HandlerMessageReactionRemoveAllErr:
+		return t(d.session, evt.(*MessageReactionRemoveAll))
 	case chan *MessageReactionRemoveAll:
 		t <- evt.(*MessageReactionRemoveAll)
 	case chan<- *MessageReactionRemoveAll:
 		t <- evt.(*MessageReactionRemoveAll)
 	case HandlerMessageReactionRemoveEmoji:
 		t(d.session, evt.(*MessageReactionRemoveEmoji))
+	case HandlerMessageReactionRemoveEmojiErr:
+		return t(d.session, evt.(*MessageReactionRemoveEmoji))
 	case chan *MessageReactionRemoveEmoji:
 		t <- evt.(*MessageReactionRemoveEmoji)
 	case chan<- *MessageReactionRemoveEmoji:
 		t <- evt.(*MessageReactionRemoveEmoji)
 	case HandlerMessageUpdate:
 		t(d.session, evt.(*MessageUpdate))
+	case HandlerMessageUpdateErr:
+		return t(d.session, evt.(*MessageUpdate))
 	case chan *MessageUpdate:
 		t <- evt.(*MessageUpdate)
 	case chan<- *MessageUpdate:
 		t <- evt.(*MessageUpdate)
 	case HandlerPresenceUpdate:
 		t(d.session, evt.(*PresenceUpdate))
+	case HandlerPresenceUpdateErr:
+		return t(d.session, evt.(*PresenceUpdate))
 	case chan *PresenceUpdate:
 		t <- evt.(*PresenceUpdate)
 	case chan<- *PresenceUpdate:
 		t <- evt.(*PresenceUpdate)
 	case HandlerReady:
 		t(d.session, evt.(*Ready))
+	case HandlerReadyErr:
+		return t(d.session, evt.(*Ready))
 	case chan *Ready:
 		t <- evt.(*Ready)
 	case chan<- *Ready:
 		t <- evt.(*Ready)
 	case HandlerResumed:
 		t(d.session, evt.(*Resumed))
+	case HandlerResumedErr:
+		return t(d.session, evt.(*Resumed))
 	case chan *Resumed:
 		t <- evt.(*Resumed)
 	case chan<- *Resumed:
 		t <- evt.(*Resumed)
+	case HandlerShardConnected:
+		t(d.session, evt.(*ShardConnected))
+	case HandlerShardConnectedErr:
+		return t(d.session, evt.(*ShardConnected))
+	case chan *ShardConnected:
+		t <- evt.(*ShardConnected)
+	case chan<- *ShardConnected:
+		t <- evt.(*ShardConnected)
+	case HandlerShardDisconnected:
+		t(d.session, evt.(*ShardDisconnected))
+	case HandlerShardDisconnectedErr:
+		return t(d.session, evt.(*ShardDisconnected))
+	case chan *ShardDisconnected:
+		t <- evt.(*ShardDisconnected)
+	case chan<- *ShardDisconnected:
+		t <- evt.(*ShardDisconnected)
+	case HandlerShardResumed:
+		t(d.session, evt.(*ShardResumed))
+	case HandlerShardResumedErr:
+		return t(d.session, evt.(*ShardResumed))
+	case chan *ShardResumed:
+		t <- evt.(*ShardResumed)
+	case chan<- *ShardResumed:
+		t <- evt.(*ShardResumed)
+	case HandlerShardScaled:
+		t(d.session, evt.(*ShardScaled))
+	case HandlerShardScaledErr:
+		return t(d.session, evt.(*ShardScaled))
+	case chan *ShardScaled:
+		t <- evt.(*ShardScaled)
+	case chan<- *ShardScaled:
+		t <- evt.(*ShardScaled)
 	case HandlerTypingStart:
 		t(d.session, evt.(*TypingStart))
+	case HandlerTypingStartErr:
+		return t(d.session, evt.(*TypingStart))
 	case chan *TypingStart:
 		t <- evt.(*TypingStart)
 	case chan<- *TypingStart:
 		t <- evt.(*TypingStart)
 	case HandlerUserUpdate:
 		t(d.session, evt.(*UserUpdate))
+	case HandlerUserUpdateErr:
+		return t(d.session, evt.(*UserUpdate))
 	case chan *UserUpdate:
 		t <- evt.(*UserUpdate)
 	case chan<- *UserUpdate:
 		t <- evt.(*UserUpdate)
+	case HandlerVoiceClientDisconnect:
+		t(d.session, evt.(*VoiceClientDisconnect))
+	case HandlerVoiceClientDisconnectErr:
+		return t(d.session, evt.(*VoiceClientDisconnect))
+	case chan *VoiceClientDisconnect:
+		t <- evt.(*VoiceClientDisconnect)
+	case chan<- *VoiceClientDisconnect:
+		t <- evt.(*VoiceClientDisconnect)
 	case HandlerVoiceServerUpdate:
 		t(d.session, evt.(*VoiceServerUpdate))
+	case HandlerVoiceServerUpdateErr:
+		return t(d.session, evt.(*VoiceServerUpdate))
 	case chan *VoiceServerUpdate:
 		t <- evt.(*VoiceServerUpdate)
 	case chan<- *VoiceServerUpdate:
 		t <- evt.(*VoiceServerUpdate)
+	case HandlerVoiceSpeaking:
+		t(d.session, evt.(*VoiceSpeaking))
+	case HandlerVoiceSpeakingErr:
+		return t(d.session, evt.(*VoiceSpeaking))
+	case chan *VoiceSpeaking:
+		t <- evt.(*VoiceSpeaking)
+	case chan<- *VoiceSpeaking:
+		t <- evt.(*VoiceSpeaking)
 	case HandlerVoiceStateUpdate:
 		t(d.session, evt.(*VoiceStateUpdate))
+	case HandlerVoiceStateUpdateErr:
+		return t(d.session, evt.(*VoiceStateUpdate))
 	case chan *VoiceStateUpdate:
 		t <- evt.(*VoiceStateUpdate)
 	case chan<- *VoiceStateUpdate:
 		t <- evt.(*VoiceStateUpdate)
 	case HandlerWebhooksUpdate:
 		t(d.session, evt.(*WebhooksUpdate))
+	case HandlerWebhooksUpdateErr:
+		return t(d.session, evt.(*WebhooksUpdate))
 	case chan *WebhooksUpdate:
 		t <- evt.(*WebhooksUpdate)
 	case chan<- *WebhooksUpdate:
 		t <- evt.(*WebhooksUpdate)
 	}
+	return nil
 }
 
 //////////////////////////////////////////////////////
@@ -597,110 +875,257 @@ type HandlerSimple = func(Session)
 // HandlerChannelCreate is triggered by ChannelCreate events
 type HandlerChannelCreate = func(s Session, h *ChannelCreate)
 
+// HandlerChannelCreateErr is triggered by ChannelCreate events, and reports the returned error to Config.OnHandlerError
+type HandlerChannelCreateErr = func(s Session, h *ChannelCreate) error
+
 // HandlerChannelDelete is triggered by ChannelDelete events
 type HandlerChannelDelete = func(s Session, h *ChannelDelete)
 
+// HandlerChannelDeleteErr is triggered by ChannelDelete events, and reports the returned error to Config.OnHandlerError
+type HandlerChannelDeleteErr = func(s Session, h *ChannelDelete) error
+
 // HandlerChannelPinsUpdate is triggered by ChannelPinsUpdate events
 type HandlerChannelPinsUpdate = func(s Session, h *ChannelPinsUpdate)
 
+// HandlerChannelPinsUpdateErr is triggered by ChannelPinsUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerChannelPinsUpdateErr = func(s Session, h *ChannelPinsUpdate) error
+
 // HandlerChannelUpdate is triggered by ChannelUpdate events
 type HandlerChannelUpdate = func(s Session, h *ChannelUpdate)
 
+// HandlerChannelUpdateErr is triggered by ChannelUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerChannelUpdateErr = func(s Session, h *ChannelUpdate) error
+
 // HandlerGuildBanAdd is triggered by GuildBanAdd events
 type HandlerGuildBanAdd = func(s Session, h *GuildBanAdd)
 
+// HandlerGuildBanAddErr is triggered by GuildBanAdd events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildBanAddErr = func(s Session, h *GuildBanAdd) error
+
 // HandlerGuildBanRemove is triggered by GuildBanRemove events
 type HandlerGuildBanRemove = func(s Session, h *GuildBanRemove)
 
+// HandlerGuildBanRemoveErr is triggered by GuildBanRemove events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildBanRemoveErr = func(s Session, h *GuildBanRemove) error
+
 // HandlerGuildCreate is triggered by GuildCreate events
 type HandlerGuildCreate = func(s Session, h *GuildCreate)
 
+// HandlerGuildCreateErr is triggered by GuildCreate events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildCreateErr = func(s Session, h *GuildCreate) error
+
 // HandlerGuildDelete is triggered by GuildDelete events
 type HandlerGuildDelete = func(s Session, h *GuildDelete)
 
+// HandlerGuildDeleteErr is triggered by GuildDelete events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildDeleteErr = func(s Session, h *GuildDelete) error
+
 // HandlerGuildEmojisUpdate is triggered by GuildEmojisUpdate events
 type HandlerGuildEmojisUpdate = func(s Session, h *GuildEmojisUpdate)
 
+// HandlerGuildEmojisUpdateErr is triggered by GuildEmojisUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildEmojisUpdateErr = func(s Session, h *GuildEmojisUpdate) error
+
 // HandlerGuildIntegrationsUpdate is triggered by GuildIntegrationsUpdate events
 type HandlerGuildIntegrationsUpdate = func(s Session, h *GuildIntegrationsUpdate)
 
+// HandlerGuildIntegrationsUpdateErr is triggered by GuildIntegrationsUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildIntegrationsUpdateErr = func(s Session, h *GuildIntegrationsUpdate) error
+
 // HandlerGuildMemberAdd is triggered by GuildMemberAdd events
 type HandlerGuildMemberAdd = func(s Session, h *GuildMemberAdd)
 
+// HandlerGuildMemberAddErr is triggered by GuildMemberAdd events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildMemberAddErr = func(s Session, h *GuildMemberAdd) error
+
 // HandlerGuildMemberRemove is triggered by GuildMemberRemove events
 type HandlerGuildMemberRemove = func(s Session, h *GuildMemberRemove)
 
+// HandlerGuildMemberRemoveErr is triggered by GuildMemberRemove events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildMemberRemoveErr = func(s Session, h *GuildMemberRemove) error
+
 // HandlerGuildMemberUpdate is triggered by GuildMemberUpdate events
 type HandlerGuildMemberUpdate = func(s Session, h *GuildMemberUpdate)
 
+// HandlerGuildMemberUpdateErr is triggered by GuildMemberUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildMemberUpdateErr = func(s Session, h *GuildMemberUpdate) error
+
 // HandlerGuildMembersChunk is triggered by GuildMembersChunk events
 type HandlerGuildMembersChunk = func(s Session, h *GuildMembersChunk)
 
+// HandlerGuildMembersChunkErr is triggered by GuildMembersChunk events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildMembersChunkErr = func(s Session, h *GuildMembersChunk) error
+
 // HandlerGuildRoleCreate is triggered by GuildRoleCreate events
 type HandlerGuildRoleCreate = func(s Session, h *GuildRoleCreate)
 
+// HandlerGuildRoleCreateErr is triggered by GuildRoleCreate events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildRoleCreateErr = func(s Session, h *GuildRoleCreate) error
+
 // HandlerGuildRoleDelete is triggered by GuildRoleDelete events
 type HandlerGuildRoleDelete = func(s Session, h *GuildRoleDelete)
 
+// HandlerGuildRoleDeleteErr is triggered by GuildRoleDelete events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildRoleDeleteErr = func(s Session, h *GuildRoleDelete) error
+
 // HandlerGuildRoleUpdate is triggered by GuildRoleUpdate events
 type HandlerGuildRoleUpdate = func(s Session, h *GuildRoleUpdate)
 
+// HandlerGuildRoleUpdateErr is triggered by GuildRoleUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildRoleUpdateErr = func(s Session, h *GuildRoleUpdate) error
+
 // HandlerGuildUpdate is triggered by GuildUpdate events
 type HandlerGuildUpdate = func(s Session, h *GuildUpdate)
 
+// HandlerGuildUpdateErr is triggered by GuildUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerGuildUpdateErr = func(s Session, h *GuildUpdate) error
+
 // HandlerInteractionCreate is triggered by InteractionCreate events
 type HandlerInteractionCreate = func(s Session, h *InteractionCreate)
 
+// HandlerInteractionCreateErr is triggered by InteractionCreate events, and reports the returned error to Config.OnHandlerError
+type HandlerInteractionCreateErr = func(s Session, h *InteractionCreate) error
+
 // HandlerInviteCreate is triggered by InviteCreate events
 type HandlerInviteCreate = func(s Session, h *InviteCreate)
 
+// HandlerInviteCreateErr is triggered by InviteCreate events, and reports the returned error to Config.OnHandlerError
+type HandlerInviteCreateErr = func(s Session, h *InviteCreate) error
+
 // HandlerInviteDelete is triggered by InviteDelete events
 type HandlerInviteDelete = func(s Session, h *InviteDelete)
 
+// HandlerInviteDeleteErr is triggered by InviteDelete events, and reports the returned error to Config.OnHandlerError
+type HandlerInviteDeleteErr = func(s Session, h *InviteDelete) error
+
 // HandlerMessageCreate is triggered by MessageCreate events
 type HandlerMessageCreate = func(s Session, h *MessageCreate)
 
+// HandlerMessageCreateErr is triggered by MessageCreate events, and reports the returned error to Config.OnHandlerError
+type HandlerMessageCreateErr = func(s Session, h *MessageCreate) error
+
 // HandlerMessageDelete is triggered by MessageDelete events
 type HandlerMessageDelete = func(s Session, h *MessageDelete)
 
+// HandlerMessageDeleteErr is triggered by MessageDelete events, and reports the returned error to Config.OnHandlerError
+type HandlerMessageDeleteErr = func(s Session, h *MessageDelete) error
+
 // HandlerMessageDeleteBulk is triggered by MessageDeleteBulk events
 type HandlerMessageDeleteBulk = func(s Session, h *MessageDeleteBulk)
 
+// HandlerMessageDeleteBulkErr is triggered by MessageDeleteBulk events, and reports the returned error to Config.OnHandlerError
+type HandlerMessageDeleteBulkErr = func(s Session, h *MessageDeleteBulk) error
+
 // HandlerMessageReactionAdd is triggered by MessageReactionAdd events
 type HandlerMessageReactionAdd = func(s Session, h *MessageReactionAdd)
 
+// HandlerMessageReactionAddErr is triggered by MessageReactionAdd events, and reports the returned error to Config.OnHandlerError
+type HandlerMessageReactionAddErr = func(s Session, h *MessageReactionAdd) error
+
 // HandlerMessageReactionRemove is triggered by MessageReactionRemove events
 type HandlerMessageReactionRemove = func(s Session, h *MessageReactionRemove)
 
+// HandlerMessageReactionRemoveErr is triggered by MessageReactionRemove events, and reports the returned error to Config.OnHandlerError
+type HandlerMessageReactionRemoveErr = func(s Session, h *MessageReactionRemove) error
+
 // HandlerMessageReactionRemoveAll is triggered by MessageReactionRemoveAll events
 type HandlerMessageReactionRemoveAll = func(s Session, h *MessageReactionRemoveAll)
 
+// HandlerMessageReactionRemoveAllErr is triggered by MessageReactionRemoveAll events, and reports the returned error to Config.OnHandlerError
+type HandlerMessageReactionRemoveAllErr = func(s Session, h *MessageReactionRemoveAll) error
+
 // HandlerMessageReactionRemoveEmoji is triggered by MessageReactionRemoveEmoji events
 type HandlerMessageReactionRemoveEmoji = func(s Session, h *MessageReactionRemoveEmoji)
 
+// HandlerMessageReactionRemoveEmojiErr is triggered by MessageReactionRemoveEmoji events, and reports the returned error to Config.OnHandlerError
+type HandlerMessageReactionRemoveEmojiErr = func(s Session, h *MessageReactionRemoveEmoji) error
+
 // HandlerMessageUpdate is triggered by MessageUpdate events
 type HandlerMessageUpdate = func(s Session, h *MessageUpdate)
 
+// HandlerMessageUpdateErr is triggered by MessageUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerMessageUpdateErr = func(s Session, h *MessageUpdate) error
+
 // HandlerPresenceUpdate is triggered by PresenceUpdate events
 type HandlerPresenceUpdate = func(s Session, h *PresenceUpdate)
 
+// HandlerPresenceUpdateErr is triggered by PresenceUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerPresenceUpdateErr = func(s Session, h *PresenceUpdate) error
+
 // HandlerReady is triggered by Ready events
 type HandlerReady = func(s Session, h *Ready)
 
+// HandlerReadyErr is triggered by Ready events, and reports the returned error to Config.OnHandlerError
+type HandlerReadyErr = func(s Session, h *Ready) error
+
 // HandlerResumed is triggered by Resumed events
 type HandlerResumed = func(s Session, h *Resumed)
 
+// HandlerResumedErr is triggered by Resumed events, and reports the returned error to Config.OnHandlerError
+type HandlerResumedErr = func(s Session, h *Resumed) error
+
+// HandlerShardConnected is triggered by ShardConnected events
+type HandlerShardConnected = func(s Session, h *ShardConnected)
+
+// HandlerShardConnectedErr is triggered by ShardConnected events, and reports the returned error to Config.OnHandlerError
+type HandlerShardConnectedErr = func(s Session, h *ShardConnected) error
+
+// HandlerShardDisconnected is triggered by ShardDisconnected events
+type HandlerShardDisconnected = func(s Session, h *ShardDisconnected)
+
+// HandlerShardDisconnectedErr is triggered by ShardDisconnected events, and reports the returned error to Config.OnHandlerError
+type HandlerShardDisconnectedErr = func(s Session, h *ShardDisconnected) error
+
+// HandlerShardResumed is triggered by ShardResumed events
+type HandlerShardResumed = func(s Session, h *ShardResumed)
+
+// HandlerShardResumedErr is triggered by ShardResumed events, and reports the returned error to Config.OnHandlerError
+type HandlerShardResumedErr = func(s Session, h *ShardResumed) error
+
+// HandlerShardScaled is triggered by ShardScaled events
+type HandlerShardScaled = func(s Session, h *ShardScaled)
+
+// HandlerShardScaledErr is triggered by ShardScaled events, and reports the returned error to Config.OnHandlerError
+type HandlerShardScaledErr = func(s Session, h *ShardScaled) error
+
 // HandlerTypingStart is triggered by TypingStart events
 type HandlerTypingStart = func(s Session, h *TypingStart)
 
+// HandlerTypingStartErr is triggered by TypingStart events, and reports the returned error to Config.OnHandlerError
+type HandlerTypingStartErr = func(s Session, h *TypingStart) error
+
 // HandlerUserUpdate is triggered by UserUpdate events
 type HandlerUserUpdate = func(s Session, h *UserUpdate)
 
+// HandlerUserUpdateErr is triggered by UserUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerUserUpdateErr = func(s Session, h *UserUpdate) error
+
+// HandlerVoiceClientDisconnect is triggered by VoiceClientDisconnect events
+type HandlerVoiceClientDisconnect = func(s Session, h *VoiceClientDisconnect)
+
+// HandlerVoiceClientDisconnectErr is triggered by VoiceClientDisconnect events, and reports the returned error to Config.OnHandlerError
+type HandlerVoiceClientDisconnectErr = func(s Session, h *VoiceClientDisconnect) error
+
 // HandlerVoiceServerUpdate is triggered by VoiceServerUpdate events
 type HandlerVoiceServerUpdate = func(s Session, h *VoiceServerUpdate)
 
+// HandlerVoiceServerUpdateErr is triggered by VoiceServerUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerVoiceServerUpdateErr = func(s Session, h *VoiceServerUpdate) error
+
+// HandlerVoiceSpeaking is triggered by VoiceSpeaking events
+type HandlerVoiceSpeaking = func(s Session, h *VoiceSpeaking)
+
+// HandlerVoiceSpeakingErr is triggered by VoiceSpeaking events, and reports the returned error to Config.OnHandlerError
+type HandlerVoiceSpeakingErr = func(s Session, h *VoiceSpeaking) error
+
 // HandlerVoiceStateUpdate is triggered by VoiceStateUpdate events
 type HandlerVoiceStateUpdate = func(s Session, h *VoiceStateUpdate)
 
+// HandlerVoiceStateUpdateErr is triggered by VoiceStateUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerVoiceStateUpdateErr = func(s Session, h *VoiceStateUpdate) error
+
 // HandlerWebhooksUpdate is triggered by WebhooksUpdate events
 type HandlerWebhooksUpdate = func(s Session, h *WebhooksUpdate)
+
+// HandlerWebhooksUpdateErr is triggered by WebhooksUpdate events, and reports the returned error to Config.OnHandlerError
+type HandlerWebhooksUpdateErr = func(s Session, h *WebhooksUpdate) error
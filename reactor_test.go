@@ -3,8 +3,15 @@
 package disgord
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord/internal/event"
+	"github.com/andersfylling/disgord/internal/gateway"
 )
 
 func Test_isHandler(t *testing.T) {
@@ -73,3 +80,275 @@ func TestCtrl_CloseChannel(t *testing.T) {
 	// should not hang
 	d.dispatch(EvtMessageCreate, &MessageCreate{})
 }
+
+func TestDispatchPool_ordered(t *testing.T) {
+	c := New(Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		Cache:        &CacheNop{},
+		DispatchConfig: DispatchConfig{
+			Mode:      DispatchOrderedPerGuild,
+			Workers:   3,
+			QueueSize: 1,
+		},
+	})
+	defer close(c.dispatcher.shutdown)
+
+	input := make(chan *gateway.Event)
+	go c.demultiplexer(c.dispatcher, input)
+
+	const guilds, messages = 4, 20
+	var mu sync.Mutex
+	received := make(map[Snowflake][]Snowflake)
+	done := make(chan struct{})
+	c.Gateway().MessageCreate(func(_ Session, evt *MessageCreate) {
+		time.Sleep(time.Millisecond) // a slow handler, such that the queues fill up
+		mu.Lock()
+		defer mu.Unlock()
+		received[evt.Message.GuildID] = append(received[evt.Message.GuildID], evt.Message.ID)
+		if len(received[evt.Message.GuildID]) == messages && len(received) == guilds {
+			var total int
+			for _, ids := range received {
+				total += len(ids)
+			}
+			if total == guilds*messages {
+				close(done)
+			}
+		}
+	})
+
+	for i := 1; i <= messages; i++ {
+		for guildID := 1; guildID <= guilds; guildID++ {
+			data := fmt.Sprintf(`{"id":"%d","guild_id":"%d","channel_id":"%d"}`, i, guildID, 100+guildID)
+			input <- &gateway.Event{Name: EvtMessageCreate, Data: []byte(data)}
+		}
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("not every event was dispatched")
+	}
+
+	for guildID, ids := range received {
+		for i, id := range ids {
+			if id != Snowflake(i+1) {
+				t.Fatalf("expected the messages of guild %d to be in order, got %v", guildID, ids)
+			}
+		}
+	}
+
+	stats := c.DispatchStats()
+	if stats.Dispatched != guilds*messages || stats.QueueCapacity != 3 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if stats.Blocked == 0 || stats.BlockedTime == 0 {
+		t.Errorf("expected the full queues to block, got %+v", stats)
+	}
+}
+
+func TestDispatchPool_key(t *testing.T) {
+	p := &dispatchPool{mode: DispatchOrderedPerChannel}
+	events := []struct {
+		resource resource
+		expected Snowflake
+	}{
+		{&MessageCreate{Message: &Message{ID: 1, GuildID: 2, ChannelID: 3}}, 3},
+		{&GuildMemberAdd{Member: &Member{GuildID: 2, UserID: 1}}, 2},
+		{&GuildCreate{Guild: &Guild{ID: 2}}, 2},
+		{&ChannelUpdate{Channel: &Channel{ID: 3, GuildID: 2}}, 3},
+		{&MessageReactionAdd{GuildID: 2, ChannelID: 3}, 3},
+		{&GuildMemberUpdate{}, 0},
+		{&Ready{}, 0},
+	}
+	for _, evt := range events {
+		if key := p.key(evt.resource); key != evt.expected {
+			t.Errorf("%T: expected key %d, got %d", evt.resource, evt.expected, key)
+		}
+	}
+
+	p.mode = DispatchOrderedPerGuild
+	if key := p.key(&ChannelUpdate{Channel: &Channel{ID: 3, GuildID: 2}}); key != 2 {
+		t.Errorf("expected the guild to be the key, got %d", key)
+	}
+}
+
+// setGuildID sets the GuildID field of the event, or of a struct it points to. It reports whether the event has
+// a guild ID.
+func setGuildID(evt reflect.Value, guildID Snowflake, depth int) bool {
+	if field, ok := evt.Type().FieldByName("GuildID"); ok && len(field.Index) == 1 && field.Type == reflect.TypeOf(guildID) {
+		evt.Field(field.Index[0]).Set(reflect.ValueOf(guildID))
+		return true
+	}
+	if depth == 0 {
+		return false
+	}
+	for i := 0; i < evt.NumField(); i++ {
+		field := evt.Field(i)
+		if field.Kind() != reflect.Ptr || field.Type().Elem().Kind() != reflect.Struct || !field.CanSet() {
+			continue
+		}
+		value := reflect.New(field.Type().Elem())
+		if setGuildID(value.Elem(), guildID, depth-1) {
+			field.Set(value)
+			return true
+		}
+	}
+	return false
+}
+
+func TestDispatchPool_keyAllEvents(t *testing.T) {
+	// every event with a guild ID must be ordered by its guild
+	p := &dispatchPool{mode: DispatchOrderedPerGuild}
+	for _, name := range append(AllEvents(), event.Disgord()...) {
+		evt := defineResource(name)
+		if evt == nil || !setGuildID(reflect.ValueOf(evt).Elem(), 2, 1) {
+			continue
+		}
+		if key := p.key(evt); key != 2 {
+			t.Errorf("%s: expected the guild ID to be the key, got %d", name, key)
+		}
+	}
+}
+
+func TestDispatchPool_drop(t *testing.T) {
+	c := New(Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		Cache:        &CacheNop{},
+		DispatchConfig: DispatchConfig{
+			Mode:         DispatchOrderedPerGuild,
+			Workers:      1,
+			QueueSize:    1,
+			QueueTimeout: 10 * time.Millisecond,
+		},
+	})
+	defer close(c.dispatcher.shutdown)
+
+	release := make(chan struct{})
+	received := make(chan Snowflake, 10)
+	c.Gateway().MessageCreate(func(_ Session, evt *MessageCreate) {
+		<-release
+		received <- evt.Message.ID
+	})
+
+	input := make(chan *gateway.Event)
+	go c.demultiplexer(c.dispatcher, input)
+
+	// the first event blocks the worker and the second fills the queue
+	for i := 1; i <= 5; i++ {
+		data := fmt.Sprintf(`{"id":"%d","guild_id":"1","channel_id":"2"}`, i)
+		select {
+		case input <- &gateway.Event{Name: EvtMessageCreate, Data: []byte(data)}:
+		case <-time.After(time.Second):
+			t.Fatal("a full queue should not stop the events from being read")
+		}
+	}
+
+	// the last event might still be processed by the demultiplexer
+	for deadline := time.Now().Add(time.Second); c.DispatchStats().Dropped < 3; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected three events to be dropped, got %+v", c.DispatchStats())
+		}
+		<-time.After(time.Millisecond)
+	}
+	if stats := c.DispatchStats(); stats.Dispatched != 2 || stats.Blocked == 0 {
+		t.Errorf("expected two events to be dispatched after waiting, got %+v", stats)
+	}
+
+	close(release)
+	for _, expected := range []Snowflake{1, 2} {
+		select {
+		case id := <-received:
+			if id != expected {
+				t.Errorf("expected message %d, got %d", expected, id)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("the queued events were not dispatched")
+		}
+	}
+}
+
+func TestDispatcher_handlerErrors(t *testing.T) {
+	var errs []*HandlerError
+	c := New(Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		Cache:        &CacheNop{},
+		OnHandlerError: func(err *HandlerError) {
+			errs = append(errs, err)
+		},
+	})
+
+	failed := errors.New("failed")
+	var ran bool
+	c.Gateway().MessageCreate(func(_ Session, _ *MessageCreate) {
+		panic("oops")
+	}, func(_ Session, _ *MessageCreate) {
+		ran = true
+	})
+	c.Gateway().MessageCreateErr(func(_ Session, _ *MessageCreate) error {
+		return failed
+	}, func(_ Session, _ *MessageCreate) error {
+		return nil
+	})
+
+	c.dispatcher.dispatch(EvtMessageCreate, &MessageCreate{Message: &Message{}, ShardID: 2})
+
+	if !ran {
+		t.Error("a panic should not stop the other handlers")
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 handler errors, got %d", len(errs))
+	}
+	if errs[0].Panic != "oops" || len(errs[0].Stack) == 0 || errs[0].Event != EvtMessageCreate || errs[0].ShardID != 2 {
+		t.Errorf("expected the panic to be reported, got %+v", errs[0])
+	}
+	if !errors.Is(errs[1], failed) || errs[1].Panic != nil || errs[1].ShardID != 2 {
+		t.Errorf("expected the returned error to be reported, got %+v", errs[1])
+	}
+}
+
+func TestDispatcher_middlewarePanic(t *testing.T) {
+	var errs []*HandlerError
+	c := New(Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		Cache:        &CacheNop{},
+		OnHandlerError: func(err *HandlerError) {
+			errs = append(errs, err)
+		},
+	})
+
+	var filtered, ran bool
+	c.Gateway().WithMiddleware(func(evt interface{}) interface{} {
+		panic("oops")
+	}).MessageCreate(func(_ Session, _ *MessageCreate) {
+		filtered = true
+	})
+	c.Gateway().MessageCreate(func(_ Session, _ *MessageCreate) {
+		ran = true
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.dispatcher.dispatch(EvtMessageCreate, &MessageCreate{Message: &Message{}, ShardID: 2})
+		c.dispatcher.dispatch(EvtMessageCreate, &MessageCreate{Message: &Message{}, ShardID: 2})
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the handler spec should be unlocked after a middleware panicked")
+	}
+
+	if filtered || !ran {
+		t.Error("expected only the handlers after the panicking middleware to be skipped")
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 handler errors, got %d", len(errs))
+	}
+	if errs[0].Panic != "oops" || len(errs[0].Stack) == 0 || errs[0].ShardID != 2 {
+		t.Errorf("expected the panic to be reported, got %+v", errs[0])
+	}
+}
@@ -0,0 +1,300 @@
+package disgord
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/andersfylling/disgord/internal/gateway"
+)
+
+// DispatchMode decides whether events can reach the handlers out of order.
+type DispatchMode uint8
+
+const (
+	// DispatchConcurrent dispatches every event in a new goroutine. Events are not ordered, such that a
+	// MessageUpdate can reach the handlers before the MessageCreate. This is the default.
+	DispatchConcurrent DispatchMode = iota
+
+	// DispatchOrderedPerGuild dispatches the events of a guild one at a time, in the order they were received.
+	// Events without a guild, such as direct messages and shard events, are ordered among themselves.
+	DispatchOrderedPerGuild
+
+	// DispatchOrderedPerChannel dispatches the events of a channel one at a time, in the order they were
+	// received. Events without a channel, such as GuildMemberAdd, are ordered per guild.
+	DispatchOrderedPerChannel
+)
+
+// DispatchConfig configures how events are passed to the handlers. In the ordered modes, events are queued
+// for a fixed number of workers, where every guild or channel belongs to a single worker. A handler that is
+// slow, or a channel that is not read from, therefore delays the following events of every guild on the same
+// worker. When a queue is full, the event waits for room for up to QueueTimeout and is dropped afterwards,
+// such that a stalled worker does not stop the shards from reading the gateway. Until the queue has room
+// again, the following events for it are dropped without waiting, see Client.DispatchStats.
+//
+// GuildMembersChunk, VoiceStateUpdate and VoiceServerUpdate events are always dispatched concurrently, as
+// Disgord waits for them when requesting guild members or connecting to a voice channel, which might happen
+// from within a handler.
+type DispatchConfig struct {
+	Mode DispatchMode
+
+	// Workers is the number of goroutines that run the handlers. Defaults to 4 times the number of CPUs.
+	Workers int
+
+	// QueueSize is the number of events that can wait for each worker. Defaults to 100.
+	QueueSize int
+
+	// QueueTimeout is how long an event waits for room in a full queue before it is dropped. Defaults to
+	// one second.
+	QueueTimeout time.Duration
+}
+
+// DispatchStats holds the backpressure metrics of the ordered dispatch modes.
+type DispatchStats struct {
+	// Queued is the number of events that are waiting for a worker, and QueueCapacity is the number of
+	// events that can wait in total.
+	Queued        int
+	QueueCapacity int
+
+	// Dispatched is the number of events that were passed to the workers.
+	Dispatched uint64
+
+	// Blocked is the number of times an event had to wait for room in a full queue, and BlockedTime is
+	// the total time spent waiting.
+	Blocked     uint64
+	BlockedTime time.Duration
+
+	// Dropped is the number of events that were discarded, as their queue stayed full.
+	Dropped uint64
+}
+
+// DispatchStats returns the backpressure metrics of the event dispatching. The metrics are zero unless an
+// ordered dispatch mode is used, see DispatchConfig.
+func (c *Client) DispatchStats() DispatchStats {
+	if c.dispatchPool == nil {
+		return DispatchStats{}
+	}
+	return c.dispatchPool.stats()
+}
+
+const (
+	defaultDispatchQueueSize    = 100
+	defaultDispatchQueueTimeout = time.Second
+)
+
+// dispatchPool runs the handlers of the events with the same key on the same worker, in order.
+type dispatchPool struct {
+	d       *dispatcher
+	mode    DispatchMode
+	queues  []chan *dispatchJob
+	timeout time.Duration
+
+	// stalled marks the queues that stayed full, such that events are dropped without waiting
+	stalled []atomic.Bool
+
+	dispatched  atomic.Uint64
+	blocked     atomic.Uint64
+	blockedTime atomic.Int64
+	dropped     atomic.Uint64
+}
+
+type dispatchJob struct {
+	name     string
+	resource resource
+}
+
+func newDispatchPool(d *dispatcher, conf DispatchConfig) *dispatchPool {
+	workers := conf.Workers
+	if workers <= 0 {
+		workers = 4 * runtime.NumCPU()
+	}
+	queueSize := conf.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultDispatchQueueSize
+	}
+	timeout := conf.QueueTimeout
+	if timeout <= 0 {
+		timeout = defaultDispatchQueueTimeout
+	}
+
+	p := &dispatchPool{
+		d:       d,
+		mode:    conf.Mode,
+		queues:  make([]chan *dispatchJob, workers),
+		timeout: timeout,
+		stalled: make([]atomic.Bool, workers),
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan *dispatchJob, queueSize)
+		go p.work(p.queues[i])
+	}
+	return p
+}
+
+func (p *dispatchPool) work(queue <-chan *dispatchJob) {
+	for {
+		select {
+		case job := <-queue:
+			p.d.dispatch(job.name, job.resource)
+		case <-p.d.shutdown:
+			return
+		}
+	}
+}
+
+// dispatch queues the event for the worker of its guild or channel. While the queue is full, it waits for
+// room for up to the queue timeout and drops the event afterwards.
+func (p *dispatchPool) dispatch(evt *gateway.Event, resource resource) {
+	switch evt.Name {
+	case EvtGuildMembersChunk, EvtVoiceStateUpdate, EvtVoiceServerUpdate:
+		go p.d.dispatch(evt.Name, resource)
+		return
+	}
+
+	worker := p.worker(p.key(resource))
+	queue := p.queues[worker]
+	job := &dispatchJob{name: evt.Name, resource: resource}
+
+	select {
+	case queue <- job:
+		p.stalled[worker].Store(false)
+		p.dispatched.Inc()
+		return
+	default:
+	}
+	if p.stalled[worker].Load() {
+		p.drop(evt)
+		return
+	}
+
+	p.blocked.Inc()
+	start := time.Now()
+	defer func() {
+		p.blockedTime.Add(int64(time.Since(start)))
+	}()
+
+	timeout := time.NewTimer(p.timeout)
+	defer timeout.Stop()
+
+	select {
+	case queue <- job:
+		p.dispatched.Inc()
+	case <-timeout.C:
+		p.stalled[worker].Store(true)
+		p.drop(evt)
+	case <-p.d.shutdown:
+	}
+}
+
+func (p *dispatchPool) drop(evt *gateway.Event) {
+	p.dropped.Inc()
+	p.d.session.Logger().Error(fmt.Errorf("dispatch: dropped %s event from shard %d as the queue is full", evt.Name, evt.ShardID))
+}
+
+// key returns the guild or channel ID that decides the ordering of the event.
+func (p *dispatchPool) key(resource resource) Snowflake {
+	var guildID, channelID Snowflake
+	switch evt := resource.(type) {
+	case *GuildCreate:
+		guildID = evt.Guild.ID
+	case *GuildUpdate:
+		guildID = evt.Guild.ID
+	case *GuildDelete:
+		guildID = evt.UnavailableGuild.ID
+	case *ChannelCreate:
+		guildID, channelID = evt.Channel.GuildID, evt.Channel.ID
+	case *ChannelUpdate:
+		guildID, channelID = evt.Channel.GuildID, evt.Channel.ID
+	case *ChannelDelete:
+		guildID, channelID = evt.Channel.GuildID, evt.Channel.ID
+	case *ChannelPinsUpdate:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *TypingStart:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *InviteCreate:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *InviteDelete:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *MessageCreate:
+		guildID, channelID = evt.Message.GuildID, evt.Message.ChannelID
+	case *MessageUpdate:
+		guildID, channelID = evt.Message.GuildID, evt.Message.ChannelID
+	case *MessageDelete:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *MessageDeleteBulk:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *MessageReactionAdd:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *MessageReactionRemove:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *MessageReactionRemoveAll:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *MessageReactionRemoveEmoji:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *InteractionCreate:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *WebhooksUpdate:
+		guildID, channelID = evt.GuildID, evt.ChannelID
+	case *GuildEmojisUpdate:
+		guildID = evt.GuildID
+	case *GuildBanAdd:
+		guildID = evt.GuildID
+	case *GuildBanRemove:
+		guildID = evt.GuildID
+	case *GuildIntegrationsUpdate:
+		guildID = evt.GuildID
+	case *GuildMemberAdd:
+		guildID = evt.Member.GuildID
+	case *GuildMemberUpdate:
+		if evt.Member != nil {
+			guildID = evt.Member.GuildID
+		}
+	case *GuildMemberRemove:
+		guildID = evt.GuildID
+	case *GuildRoleCreate:
+		guildID = evt.GuildID
+	case *GuildRoleUpdate:
+		guildID = evt.GuildID
+	case *GuildRoleDelete:
+		guildID = evt.GuildID
+	case *PresenceUpdate:
+		guildID = evt.GuildID
+	case *GuildMembersChunk:
+		guildID = evt.GuildID
+	case *VoiceStateUpdate:
+		if evt.VoiceState != nil {
+			guildID = evt.GuildID
+		}
+	case *VoiceServerUpdate:
+		guildID = evt.GuildID
+	case *VoiceSpeaking:
+		guildID = evt.GuildID
+	case *VoiceClientDisconnect:
+		guildID = evt.GuildID
+	}
+
+	if p.mode == DispatchOrderedPerChannel && !channelID.IsZero() {
+		return channelID
+	}
+	return guildID
+}
+
+func (p *dispatchPool) worker(key Snowflake) int {
+	// the low bits of a snowflake are mostly zero, so the bits are mixed before picking a worker
+	hash := uint64(key) * 0x9E3779B97F4A7C15
+	return int((hash >> 32) % uint64(len(p.queues)))
+}
+
+func (p *dispatchPool) stats() (stats DispatchStats) {
+	for _, queue := range p.queues {
+		stats.Queued += len(queue)
+		stats.QueueCapacity += cap(queue)
+	}
+	stats.Dispatched = p.dispatched.Load()
+	stats.Blocked = p.blocked.Load()
+	stats.BlockedTime = time.Duration(p.blockedTime.Load())
+	stats.Dropped = p.dropped.Load()
+	return stats
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -17,6 +18,90 @@ import (
 
 type ErrRest = httd.ErrREST
 
+// ErrRestTransport is returned when a REST request failed before Discord responded, after every retry.
+type ErrRestTransport = httd.ErrTransport
+
+// RESTRetryPolicy decides whether failed REST requests are sent again. See Config.RESTRetryPolicy.
+type RESTRetryPolicy = httd.RetryPolicy
+
+// DefaultRESTRetryPolicy retries idempotent requests up to three times on transport errors and 5xx responses.
+var DefaultRESTRetryPolicy = httd.DefaultRetryPolicy
+
+// RESTInterceptor wraps every request sent to Discord. See Config.RESTInterceptors.
+type RESTInterceptor = httd.Interceptor
+
+// RESTRequestInfo describes a REST request as seen by a RESTInterceptor.
+type RESTRequestInfo = httd.RequestInfo
+
+// RESTRoundTrip sends a REST request. A RESTInterceptor must call the next RESTRoundTrip in the chain.
+type RESTRoundTrip = httd.RoundTrip
+
+// RESTRateLimitState is a snapshot of the REST rate limits. See Client.RESTRatelimitState.
+type RESTRateLimitState = httd.RateLimitState
+
+// RESTBucketState is a snapshot of a single REST rate limit bucket.
+type RESTBucketState = httd.BucketState
+
+// RESTRateLimitEvent describes a request that was rate limited or delayed. See Config.OnRESTRateLimit.
+type RESTRateLimitEvent = httd.RateLimitEvent
+
+// RESTRateLimitScope tells whether a 429 was caused by a per bot, global or shared rate limit.
+type RESTRateLimitScope = httd.RateLimitScope
+
+const (
+	RESTRateLimitScopeUser   = httd.RateLimitScopeUser
+	RESTRateLimitScopeGlobal = httd.RateLimitScopeGlobal
+	RESTRateLimitScopeShared = httd.RateLimitScopeShared
+)
+
+// RESTPriority decides the order of queued REST requests. A request with a higher priority is sent before every
+// queued request with a lower priority in the same rate limit bucket, or in any bucket while the global rate limit
+// is active. Interaction responses use RESTPriorityHigh.
+// When the rate limits are shared with NewDistributedRESTBucketManager, only the requests of the same process are
+// ordered by priority.
+//
+// Set the priority of a single request using the PriorityHigh or PriorityLow flag, or give every request using a
+// context a priority with WithPriority:
+//  client.Channel(channelID).WithContext(disgord.WithPriority(ctx, disgord.RESTPriorityLow)).CreateMessage(params)
+type RESTPriority = httd.Priority
+
+const (
+	RESTPriorityLow    = httd.PriorityLow
+	RESTPriorityNormal = httd.PriorityNormal
+	RESTPriorityHigh   = httd.PriorityHigh
+)
+
+// WithPriority returns a context that gives every REST request using it the priority. A PriorityHigh or
+// PriorityLow flag on the request takes precedence.
+func WithPriority(ctx context.Context, priority RESTPriority) context.Context {
+	return httd.WithPriority(ctx, priority)
+}
+
+// RESTStore is a key-value store, such as Redis, that holds the REST rate limits shared between processes.
+type RESTStore = httd.KVStore
+
+// NewDistributedRESTBucketManager creates a bucket manager that keeps the rate limits in the given store, so
+// several processes using the same bot token respect each others rate limits. See Config.RESTBucketManager.
+func NewDistributedRESTBucketManager(store RESTStore) httd.RESTBucketManager {
+	return httd.NewDistributedManager(store)
+}
+
+// NewMemoryRESTStore creates a RESTStore for a single process. Use ServeRESTStore to share it with others.
+func NewMemoryRESTStore() RESTStore {
+	return httd.NewMemoryStore()
+}
+
+// ServeRESTStore shares a store with the processes connected using NewTCPRESTStore. It blocks until the
+// listener is closed.
+func ServeRESTStore(l net.Listener, store RESTStore) error {
+	return httd.ServeStore(l, store)
+}
+
+// NewTCPRESTStore connects to a store shared by ServeRESTStore.
+func NewTCPRESTStore(addr string) RESTStore {
+	return httd.NewTCPStore(addr)
+}
+
 // URLQueryStringer converts a struct of values to a valid URL query string
 type URLQueryStringer interface {
 	URLQueryString() string
@@ -225,6 +310,9 @@ func (b *RESTBuilder) prepare() {
 	if flags.Ignorecache() {
 		b.IgnoreCache()
 	}
+	if priority := flags.Priority(); priority != RESTPriorityNormal {
+		b.config.Priority = priority
+	}
 	if b.config.Ctx == nil {
 		b.config.Ctx = context.Background()
 	}
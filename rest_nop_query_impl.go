@@ -26,6 +26,9 @@ func (guildQueryBuilderNop) GetChannels(flags ...Flag) ([]*Channel, error) {
 func (guildQueryBuilderNop) GetMembers(params *GetMembersParams, flags ...Flag) ([]*Member, error) {
 	return nil, nil
 }
+func (guildQueryBuilderNop) IterateMembers(flags ...Flag) *MemberIterator {
+	return nil
+}
 func (guildQueryBuilderNop) UpdateBuilder(flags ...Flag) UpdateGuildBuilder {
 	return nil
 }
@@ -50,6 +53,9 @@ func (guildQueryBuilderNop) KickVoiceParticipant(userID Snowflake) error {
 func (guildQueryBuilderNop) GetBans(flags ...Flag) ([]*Ban, error) {
 	return nil, nil
 }
+func (guildQueryBuilderNop) IterateBans(flags ...Flag) *BanIterator {
+	return nil
+}
 func (guildQueryBuilderNop) GetBan(userID Snowflake, flags ...Flag) (*Ban, error) {
 	return nil, nil
 }
@@ -104,6 +110,9 @@ func (guildQueryBuilderNop) GetVanityURL(flags ...Flag) (*PartialInvite, error)
 func (guildQueryBuilderNop) GetAuditLogs(flags ...Flag) GuildAuditLogsBuilder {
 	return nil
 }
+func (guildQueryBuilderNop) IterateAuditLogs(params *IterateAuditLogsParams, flags ...Flag) *AuditLogIterator {
+	return nil
+}
 func (guildQueryBuilderNop) VoiceConnect(channelID Snowflake) (ret VoiceConnection, err error) {
 	return nil, nil
 }
@@ -143,6 +152,9 @@ func (currentUserQueryBuilderNop) UpdateBuilder(_ ...Flag) UpdateCurrentUserBuil
 func (currentUserQueryBuilderNop) GetGuilds(_ *GetCurrentUserGuildsParams, _ ...Flag) ([]*Guild, error) {
 	return nil, nil
 }
+func (currentUserQueryBuilderNop) IterateGuilds(_ ...Flag) *GuildIterator {
+	return nil
+}
 func (currentUserQueryBuilderNop) LeaveGuild(_ Snowflake, _ ...Flag) error {
 	return nil
 }
@@ -4,6 +4,8 @@ package disgord
 // Warning: This file is overwritten by the "go generate" command
 // This file holds all the basic RESTBuilder methods a builder is expected to.
 
+import "time"
+
 // GuildAuditLogsBuilder is the interface for the builder.
 type GuildAuditLogsBuilder interface {
 	Execute() (log *AuditLog, err error)
@@ -14,7 +16,11 @@ type GuildAuditLogsBuilder interface {
 	SetUserID(userID Snowflake) GuildAuditLogsBuilder
 	SetActionType(actionType uint) GuildAuditLogsBuilder
 	SetBefore(before Snowflake) GuildAuditLogsBuilder
+	SetAfter(after Snowflake) GuildAuditLogsBuilder
 	SetLimit(limit int) GuildAuditLogsBuilder
+
+	SetTimeRange(since, until time.Time) GuildAuditLogsBuilder
+	Iterate() *AuditLogIterator
 }
 
 // IgnoreCache will not fetch the data from the cache if available, and always execute a
@@ -61,6 +67,12 @@ func (b *guildAuditLogsBuilder) SetBefore(before Snowflake) GuildAuditLogsBuilde
 	return b
 }
 
+func (b *guildAuditLogsBuilder) SetAfter(after Snowflake) GuildAuditLogsBuilder {
+	b.r.addPrereq(after.IsZero(), "after can not be 0")
+	b.r.param("after", after)
+	return b
+}
+
 func (b *guildAuditLogsBuilder) SetLimit(limit int) GuildAuditLogsBuilder {
 	b.r.param("limit", limit)
 	return b
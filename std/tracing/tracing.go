@@ -0,0 +1,78 @@
+// Package tracing creates a span for every REST request sent by Disgord. The Tracer interface is a subset of
+// the OpenTelemetry tracer, so OpenTelemetry, or any other tracing library, can be used through a small adapter
+// without Disgord depending on it.
+//
+//	client := disgord.New(disgord.Config{
+//		BotToken:         token,
+//		RESTInterceptors: []disgord.RESTInterceptor{tracing.NewRESTInterceptor(tracer)},
+//	})
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Attribute keys set on every span. The http keys follow the OpenTelemetry semantic conventions.
+const (
+	AttributeHTTPMethod       = "http.method"
+	AttributeHTTPTarget       = "http.target"
+	AttributeHTTPStatusCode   = "http.status_code"
+	AttributeHTTPResponseSize = "http.response_content_length"
+	AttributeHashedEndpoint   = "disgord.hashed_endpoint"
+	AttributeBucket           = "disgord.bucket"
+	AttributeAttempt          = "disgord.attempt"
+	AttributeQueueWaitMillis  = "disgord.queue_wait_ms"
+)
+
+// Attribute is a key-value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span is a single traced operation.
+type Span interface {
+	SetAttributes(attributes ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts spans, where the span is a child of any span found in the context.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// NewRESTInterceptor creates a span for every attempt of a REST request. The span is named after the
+// hashed endpoint, eg. "GET:/channels/{id}/messages", such that requests to the same endpoint are grouped.
+func NewRESTInterceptor(tracer Tracer) disgord.RESTInterceptor {
+	return func(ctx context.Context, info *disgord.RESTRequestInfo, next disgord.RESTRoundTrip) (*http.Response, []byte, error) {
+		ctx, span := tracer.Start(ctx, info.HashedEndpoint)
+		defer span.End()
+
+		resp, body, err := next(ctx, info)
+
+		// the bucket might have been learned from the response
+		attributes := []Attribute{
+			{Key: AttributeHTTPMethod, Value: info.Method},
+			{Key: AttributeHTTPTarget, Value: info.Endpoint},
+			{Key: AttributeHashedEndpoint, Value: info.HashedEndpoint},
+			{Key: AttributeBucket, Value: info.Bucket},
+			{Key: AttributeAttempt, Value: info.Attempt},
+			{Key: AttributeQueueWaitMillis, Value: info.QueueWait.Milliseconds()},
+		}
+		if resp != nil {
+			attributes = append(attributes,
+				Attribute{Key: AttributeHTTPStatusCode, Value: resp.StatusCode},
+				Attribute{Key: AttributeHTTPResponseSize, Value: len(body)},
+			)
+		}
+		span.SetAttributes(attributes...)
+		if err != nil {
+			span.RecordError(err)
+		}
+		return resp, body, err
+	}
+}
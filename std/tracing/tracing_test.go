@@ -0,0 +1,85 @@
+// +build !integration
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+type spanMock struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *spanMock) SetAttributes(attributes ...Attribute) {
+	for _, attribute := range attributes {
+		s.attributes[attribute.Key] = attribute.Value
+	}
+}
+func (s *spanMock) RecordError(err error) { s.err = err }
+func (s *spanMock) End()                  { s.ended = true }
+
+type tracerMock struct {
+	spans []*spanMock
+}
+
+func (t *tracerMock) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &spanMock{name: name, attributes: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestNewRESTInterceptor(t *testing.T) {
+	tracer := &tracerMock{}
+	interceptor := NewRESTInterceptor(tracer)
+
+	info := &disgord.RESTRequestInfo{
+		Method:         http.MethodGet,
+		Endpoint:       "/channels/1/messages?limit=2",
+		HashedEndpoint: "GET:/channels/1/messages",
+		Attempt:        2,
+		QueueWait:      3 * time.Millisecond,
+	}
+	_, _, _ = interceptor(context.Background(), info, func(ctx context.Context, info *disgord.RESTRequestInfo) (*http.Response, []byte, error) {
+		info.Bucket = "abc"
+		return &http.Response{StatusCode: http.StatusOK}, []byte("[]"), nil
+	})
+	_, _, _ = interceptor(context.Background(), info, func(ctx context.Context, info *disgord.RESTRequestInfo) (*http.Response, []byte, error) {
+		return nil, nil, errors.New("connection reset by peer")
+	})
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != info.HashedEndpoint || !span.ended || span.err != nil {
+		t.Errorf("unexpected span %+v", span)
+	}
+	expects := map[string]interface{}{
+		AttributeHTTPStatusCode:   http.StatusOK,
+		AttributeHTTPResponseSize: 2,
+		AttributeBucket:           "abc",
+		AttributeAttempt:          2,
+		AttributeQueueWaitMillis:  int64(3),
+	}
+	for key, value := range expects {
+		if span.attributes[key] != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, span.attributes[key])
+		}
+	}
+
+	if span = tracer.spans[1]; span.err == nil || !span.ended {
+		t.Error("expected the transport error to be recorded")
+	}
+	if _, ok := span.attributes[AttributeHTTPStatusCode]; ok {
+		t.Error("status code should not be set without a response")
+	}
+}
@@ -396,6 +396,9 @@ type CurrentUserQueryBuilder interface {
 	// Requires the Guilds OAuth2 scope.
 	GetGuilds(params *GetCurrentUserGuildsParams, flags ...Flag) (ret []*Guild, err error)
 
+	// IterateGuilds returns an iterator over every guild the current user is a member of.
+	IterateGuilds(flags ...Flag) *GuildIterator
+
 	// LeaveGuild Leave a guild. Returns a 204 empty response on success.
 	LeaveGuild(id Snowflake, flags ...Flag) (err error)
 
@@ -491,8 +494,13 @@ var _ URLQueryStringer = (*GetCurrentUserGuildsParams)(nil)
 //                          Guilds a non-bot user can join. Therefore, pagination is not needed for
 //                          integrations that need to get a list of Users' Guilds.
 func (c currentUserQueryBuilder) GetGuilds(params *GetCurrentUserGuildsParams, flags ...Flag) (ret []*Guild, err error) {
+	query := ""
+	if params != nil {
+		query = params.URLQueryString()
+	}
+
 	r := c.client.newRESTRequest(&httd.Request{
-		Endpoint: endpoint.UserMeGuilds(),
+		Endpoint: endpoint.UserMeGuilds() + query,
 		Ctx:      c.ctx,
 	}, flags)
 	r.factory = func() interface{} {
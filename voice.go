@@ -172,7 +172,8 @@ type VoiceChannelQueryBuilder interface {
 	// see permissions: https://discord.com/developers/docs/topics/permissions#permissions
 	DeletePermission(overwriteID Snowflake, flags ...Flag) error
 
-	// param{deaf} is deprecated
+	// Connect joins the voice channel. The bot must not be deafened to receive audio, see VoiceConnection.Receive.
+	// If the guild already has a voice connection, it is moved to this channel and returned instead.
 	Connect(mute, deaf bool) (VoiceConnection, error)
 
 	JoinManual(mute, deaf bool) (*VoiceStateUpdate, *VoiceServerUpdate, error)
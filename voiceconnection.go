@@ -14,8 +14,6 @@ import (
 
 	"github.com/andersfylling/disgord/internal/gateway"
 	"github.com/andersfylling/disgord/internal/gateway/cmd"
-
-	"golang.org/x/crypto/nacl/secretbox"
 )
 
 type voiceRepository struct {
@@ -24,27 +22,85 @@ type voiceRepository struct {
 
 	pendingStates  map[Snowflake]chan *VoiceStateUpdate
 	pendingServers map[Snowflake]chan *VoiceServerUpdate
+
+	// active holds the open voice connection of each guild, while connecting is closed once a connection
+	// attempt for the guild has finished
+	active     map[Snowflake]*voiceImpl
+	connecting map[Snowflake]chan struct{}
 }
 
+// VoiceConnectionState describes what a voice connection is currently doing with its websocket connection.
+type VoiceConnectionState = gateway.VoiceConnectionState
+
+const (
+	VoiceConnectionStateDisconnected = gateway.VoiceConnectionStateDisconnected
+	VoiceConnectionStateIdentifying  = gateway.VoiceConnectionStateIdentifying
+	VoiceConnectionStateResuming     = gateway.VoiceConnectionStateResuming
+	VoiceConnectionStateConnected    = gateway.VoiceConnectionStateConnected
+	VoiceConnectionStateClosed       = gateway.VoiceConnectionStateClosed
+)
+
 // VoiceConnection is the interface used to interact with active voice connections.
 type VoiceConnection interface {
 	// StartSpeaking should be sent before sending voice data.
 	StartSpeaking() error
-	// StopSpeaking should be sent after sending voice data. If there's a break in the sent data, you should not simply
-	// stop sending data. Instead you have to send five frames of silence ([]byte{0xF8, 0xFF, 0xFE}) before stopping
-	// to avoid unintended Opus interpolation with subsequent transmissions.
+	// StopSpeaking should be sent after sending voice data. It blocks until the queued frames have been sent,
+	// followed by the five frames of silence that Discord requires to avoid unintended Opus interpolation with
+	// subsequent transmissions. The silence is inserted when no more frames are sent.
 	StopSpeaking() error
 
-	// SendOpusFrame sends a single frame of opus data to the UDP server. Frames are sent every 20ms with 960 samples (48kHz).
+	// SendOpusFrame queues a single frame of opus data in the jitter buffer, and blocks while the buffer is full.
+	// Frames are sent every 20ms with 960 samples (48kHz), and silence is sent when the buffer runs empty. The
+	// frame must not be modified after the call. See Config.VoiceJitterBuffer.
 	//
 	// if the bot has been disconnected or the channel removed, an error will be returned. The voice object must then be properly dealt with to avoid further issues.
 	SendOpusFrame(data []byte) error
-	// SendDCA reads from a Reader expecting a DCA encoded stream/file and sends them as frames.
+	// SendDCA reads from a Reader expecting a DCA encoded stream/file and sends them as frames. It blocks until
+	// the stream has ended, see Player for playback that can be paused or stopped.
 	SendDCA(r io.Reader) error
 
-	// MoveTo moves from the current voice channel to the given.
+	// SendStats returns the counters of the sent audio, such as the number of late and dropped frames.
+	SendStats() VoiceSendStats
+
+	// Receive returns a channel of the decrypted Opus packets sent by the other users in the voice channel.
+	// The connection must not be deafened to receive audio. Packets are dropped when the channel is full,
+	// and the channel is closed once the connection is closed.
+	Receive() <-chan *VoicePacket
+
+	// MoveTo moves from the current voice channel to the given, and keeps the current mute and deaf state.
 	MoveTo(channelID Snowflake) error
 
+	// SetSelfMute mutes or unmutes the bot in the voice channel.
+	SetSelfMute(mute bool) error
+	// SetSelfDeaf deafens or undeafens the bot in the voice channel. No audio is received while deafened.
+	SetSelfDeaf(deaf bool) error
+	SelfMute() bool
+	SelfDeaf() bool
+
+	GuildID() Snowflake
+	// ChannelID returns the current voice channel, which is updated when the bot is moved by others.
+	ChannelID() Snowflake
+	// Endpoint returns the host of the voice server, which changes when Discord moves the voice connection.
+	Endpoint() string
+	// SSRC returns the synchronization source of the audio sent by the bot.
+	SSRC() uint32
+
+	// HeartbeatLatency returns the time between sending a heartbeat to the voice server and receiving the
+	// acknowledgement.
+	HeartbeatLatency() (time.Duration, error)
+
+	// Done returns a channel that is closed once the voice connection has been closed, either by calling Close
+	// or by Discord.
+	Done() <-chan struct{}
+
+	// State returns the current state of the voice connection. Lost connections are resumed, and the
+	// connection is moved automatically when Discord changes the voice server of the guild.
+	State() VoiceConnectionState
+
+	// OnStateChange registers a callback that is called whenever the state of the voice connection changes.
+	// Opus frames sent while the connection is not connected are dropped. The callback must not block.
+	OnStateChange(cb func(state VoiceConnectionState))
+
 	// Close closes the websocket and UDP connection. This VoiceConnection interface will no
 	// longer be usable.
 	// It is the callers responsibility to ensure there are no concurrent calls to any other
@@ -57,16 +113,36 @@ type voiceImpl struct {
 
 	ready atomic.Bool
 
-	ws  *gateway.VoiceClient
-	udp net.Conn
+	ws *gateway.VoiceClient
 
-	ssrc      uint32
-	secretKey [32]byte
-	send      chan []byte
-	close     chan struct{}
+	// udp, ssrc and crypto are replaced when the voice server changes, which closes udpReplaced
+	udpMu       sync.RWMutex
+	udp         net.Conn
+	ssrc        uint32
+	crypto      voiceCrypto
+	udpReplaced chan struct{}
 
-	guildID Snowflake
-	c       *Client
+	// pacer holds the jitter buffer of the frames waiting to be sent
+	pacer     *opusPacer
+	sendStats voiceSendStats
+	receive   chan *VoicePacket
+	close     chan struct{}
+	speaking  atomic.Bool
+
+	stateMu        sync.Mutex
+	stateCallbacks []func(state VoiceConnectionState)
+
+	// ssrcs links the SSRC of incoming audio to the speaking user
+	ssrcMu sync.RWMutex
+	ssrcs  map[uint32]Snowflake
+
+	// channelID, endpoint, selfMute and selfDeaf are protected by the embedded mutex
+	guildID   Snowflake
+	channelID Snowflake
+	endpoint  string
+	selfMute  bool
+	selfDeaf  bool
+	c         *Client
 }
 
 func newVoiceRepository(c *Client) (voice *voiceRepository) {
@@ -75,6 +151,8 @@ func newVoiceRepository(c *Client) (voice *voiceRepository) {
 
 		pendingStates:  make(map[Snowflake]chan *VoiceStateUpdate),
 		pendingServers: make(map[Snowflake]chan *VoiceServerUpdate),
+		active:         make(map[Snowflake]*voiceImpl),
+		connecting:     make(map[Snowflake]chan struct{}),
 	}
 	gt := c.Gateway()
 	gt.VoiceStateUpdate(voice.onVoiceStateUpdate)
@@ -93,6 +171,32 @@ func (r *voiceRepository) voiceConnectOptions(guildID, channelID Snowflake, self
 		return
 	}
 
+	// A guild can only have one voice connection, so concurrent connection attempts wait for the first one
+	// and the active connection is reused.
+	r.mu.Lock()
+	for {
+		if active, exists := r.active[guildID]; exists {
+			r.mu.Unlock()
+			return active, active.updateVoiceState(channelID, selfMute, selfDeaf)
+		}
+		attempt, exists := r.connecting[guildID]
+		if !exists {
+			break
+		}
+		r.mu.Unlock()
+		<-attempt
+		r.mu.Lock()
+	}
+	attempt := make(chan struct{})
+	r.connecting[guildID] = attempt
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.connecting, guildID)
+		r.mu.Unlock()
+		close(attempt)
+	}()
+
 	// Set up some listeners for this connection attempt
 	stateCh := make(chan *VoiceStateUpdate, 1)
 	serverCh := make(chan *VoiceServerUpdate, 1)
@@ -112,7 +216,7 @@ func (r *voiceRepository) voiceConnectOptions(guildID, channelID Snowflake, self
 	_, err = r.c.Gateway().Dispatch(UpdateVoiceState, &UpdateVoiceStatePayload{
 		GuildID:   guildID,
 		ChannelID: channelID,
-		SelfDeaf:  true, //selfDeaf,
+		SelfDeaf:  selfDeaf,
 		SelfMute:  selfMute,
 	})
 	if err != nil {
@@ -143,12 +247,23 @@ waiter:
 		}
 	}
 
-	voice := voiceImpl{
-		guildID: guildID,
-		c:       r.c,
-		send:    make(chan []byte),
-		close:   make(chan struct{}),
+	jitterBuffer := r.c.config.VoiceJitterBuffer
+	if jitterBuffer <= 0 {
+		jitterBuffer = defaultVoiceJitterBuffer
 	}
+
+	voice := voiceImpl{
+		guildID:   guildID,
+		channelID: channelID,
+		endpoint:  server.Endpoint,
+		selfMute:  selfMute,
+		selfDeaf:  selfDeaf,
+		c:         r.c,
+		receive:   make(chan *VoicePacket, voiceReceiveBuffer),
+		close:     make(chan struct{}),
+		ssrcs:     make(map[uint32]Snowflake),
+	}
+	voice.pacer = newOpusPacer(jitterBuffer, voice.close, voice.writeOpusFrame, &voice.sendStats)
 	// Defer a cleanup just in case
 	defer func(v *voiceImpl) {
 		if !v.ready.Load() {
@@ -169,9 +284,14 @@ waiter:
 		SessionID:      state.SessionID,
 		Token:          server.Token,
 		HTTPClient:     r.c.config.HTTPClient,
-		Endpoint:       "wss://" + strings.TrimSuffix(server.Endpoint, ":80") + "/?v=4",
+		Endpoint:       voiceEndpoint(server.Endpoint),
 		Logger:         r.c.log,
 		SystemShutdown: r.c.shutdownChan,
+
+		OnSpeaking:         voice.onSpeaking,
+		OnClientDisconnect: voice.onClientDisconnect,
+		OnStateChange:      voice.onStateChange,
+		OnReconnect:        voice.onReconnect,
 	})
 	if err != nil {
 		return
@@ -181,71 +301,163 @@ waiter:
 	if ready, err = voice.ws.Connect(); err != nil {
 		return
 	}
-	voice.ssrc = ready.SSRC
+	if err = voice.connectUDP(ready); err != nil {
+		return
+	}
+	voice.ready.Store(true)
+
+	go voice.opusSendLoop()
+	go voice.opusReceiveLoop()
+	go voice.watcherDiscordCloseEvt()
+
+	r.mu.Lock()
+	r.active[guildID] = &voice
+	r.mu.Unlock()
 
-	// Connect to UDP
+	ret = &voice
+	return
+}
+
+// ActiveVoiceConnection returns the open voice connection of the guild, or nil when the bot is not connected.
+// Connecting to a voice channel of a guild that already has a voice connection moves and returns the
+// existing connection, as Discord only allows one voice connection per guild.
+func (c *Client) ActiveVoiceConnection(guildID Snowflake) VoiceConnection {
+	c.voiceRepository.mu.Lock()
+	defer c.voiceRepository.mu.Unlock()
+
+	if voice, exists := c.voiceRepository.active[guildID]; exists {
+		return voice
+	}
+	return nil
+}
+
+// voiceEndpoint returns the websocket URL of a voice server endpoint given by Discord.
+func voiceEndpoint(endpoint string) string {
+	return "wss://" + strings.TrimSuffix(endpoint, ":80") + "/?v=4"
+}
+
+// connectUDP establishes a UDP connection with the voice server given in the ready payload, and selects
+// the encryption mode. A previous UDP connection is closed once the new one is ready.
+func (v *voiceImpl) connectUDP(ready *gateway.VoiceReady) (err error) {
 	dialer := net.Dial
-	if r.c.config.Proxy != nil {
-		dialer = r.c.config.Proxy.Dial
+	if v.c.config.Proxy != nil {
+		dialer = v.c.config.Proxy.Dial
 	}
-	voice.udp, err = dialer("udp", ready.IP+":"+strconv.Itoa(ready.Port))
+	udp, err := dialer("udp", ready.IP+":"+strconv.Itoa(ready.Port))
 	if err != nil {
-		return
+		return err
 	}
+	defer func() {
+		if err != nil {
+			_ = udp.Close()
+		}
+	}()
 
 	// SendOpusFrame our SSRC with no further data for the IP discovery process.
 	ssrcBuffer := make([]byte, 70)
 	binary.BigEndian.PutUint32(ssrcBuffer, ready.SSRC)
-	_, err = voice.udp.Write(ssrcBuffer)
-	if err != nil {
-		return
+	if _, err = udp.Write(ssrcBuffer); err != nil {
+		return err
 	}
 
 	ipBuffer := make([]byte, 70)
 	var n int
-	n, err = voice.udp.Read(ipBuffer)
-	if err != nil {
-		return
+	if n, err = udp.Read(ipBuffer); err != nil {
+		return err
 	}
 	if n < 70 {
-		err = errors.New("udp packet received from discord is not the required 70 bytes")
-		return
+		return errors.New("udp packet received from discord is not the required 70 bytes")
 	}
 
 	ipb := string(ipBuffer[4:68])
 	nullPos := strings.Index(ipb, "\x00")
 	if nullPos < 0 {
-		err = errors.New("udp ip discovery did not contain a null terminator")
-		return
+		return errors.New("udp ip discovery did not contain a null terminator")
 	}
 	ip := ipb[:nullPos]
 	port := binary.LittleEndian.Uint16(ipBuffer[68:70])
 
-	// Tell the websocket which encryption mode we want to use. We'll go with XSalsa20 and Poly1305 since that's what
-	// libSodium/NaCl and golang.org/x/crypto/nacl/secretbox use. If both Discord and Go both start supporting more
-	// modes "out of the box" we might want to consider implementing a "preferred mode selection" algorithm here.
+	// Tell the websocket which encryption mode we want to use, from the modes offered by Discord.
+	var mode string
+	if mode, err = selectVoiceMode(ready.Modes); err != nil {
+		return err
+	}
+
 	var session *gateway.VoiceSessionDescription
-	session, err = voice.ws.SendUDPInfo(&gateway.VoiceSelectProtocolParams{
-		Mode:    "xsalsa20_poly1305",
+	session, err = v.ws.SendUDPInfo(&gateway.VoiceSelectProtocolParams{
+		Mode:    mode,
 		Address: ip,
 		Port:    port,
 	})
 	if err != nil {
-		return
+		return err
 	}
-	if session.Mode != "xsalsa20_poly1305" {
-		err = errors.New("discord selected mismatching encryption algorithm")
-		return
+	if session.Mode != mode {
+		return errors.New("discord selected mismatching encryption algorithm")
 	}
 
-	voice.secretKey = session.SecretKey
-	voice.ready.Store(true)
+	var crypto voiceCrypto
+	if crypto, err = newVoiceCrypto(session.Mode, session.SecretKey); err != nil {
+		return err
+	}
 
-	go voice.opusSendLoop()
-	go voice.watcherDiscordCloseEvt()
+	v.udpMu.Lock()
+	previous := v.udp
+	v.udp, v.ssrc, v.crypto = udp, ready.SSRC, crypto
+	if v.udpReplaced != nil {
+		close(v.udpReplaced)
+	}
+	v.udpReplaced = make(chan struct{})
+	v.udpMu.Unlock()
+	if previous != nil {
+		_ = previous.Close()
+	}
+	return nil
+}
 
-	ret = &voice
-	return
+// transport returns the current UDP connection, SSRC and encryption.
+func (v *voiceImpl) transport() (net.Conn, uint32, voiceCrypto) {
+	v.udpMu.RLock()
+	defer v.udpMu.RUnlock()
+	return v.udp, v.ssrc, v.crypto
+}
+
+// onReconnect is called when the voice websocket had to identify again, either because the session could
+// not be resumed or because the voice server changed.
+func (v *voiceImpl) onReconnect(ready *gateway.VoiceReady) error {
+	if err := v.connectUDP(ready); err != nil {
+		return err
+	}
+
+	// the new SSRC must be linked to the bot before any audio is sent
+	if v.speaking.Load() {
+		return v.ws.Emit(cmd.VoiceSpeaking, &voiceSpeakingData{
+			Speaking: true,
+			SSRC:     ready.SSRC,
+		})
+	}
+	return nil
+}
+
+func (v *voiceImpl) onStateChange(state VoiceConnectionState) {
+	v.c.Logger().Debug("voice connection for guild ", v.guildID, " is ", state)
+
+	v.stateMu.Lock()
+	callbacks := v.stateCallbacks
+	v.stateMu.Unlock()
+	for _, cb := range callbacks {
+		cb(state)
+	}
+}
+
+func (v *voiceImpl) State() VoiceConnectionState {
+	return v.ws.State()
+}
+
+func (v *voiceImpl) OnStateChange(cb func(state VoiceConnectionState)) {
+	v.stateMu.Lock()
+	defer v.stateMu.Unlock()
+	v.stateCallbacks = append(v.stateCallbacks, cb)
 }
 
 func (r *voiceRepository) onVoiceStateUpdate(_ Session, event *VoiceStateUpdate) {
@@ -261,8 +473,19 @@ func (r *voiceRepository) onVoiceStateUpdate(_ Session, event *VoiceStateUpdate)
 		r.mu.Unlock()
 
 		ch <- event
-	} else {
-		r.mu.Unlock()
+		return
+	}
+	voice, exists := r.active[gid]
+	r.mu.Unlock()
+
+	// the bot might have been moved or muted by someone else. A bot that is removed from the voice channel
+	// is disconnected by the voice server.
+	if exists && !event.ChannelID.IsZero() {
+		voice.Lock()
+		voice.channelID = event.ChannelID
+		voice.selfMute = event.SelfMute
+		voice.selfDeaf = event.SelfDeaf
+		voice.Unlock()
 	}
 }
 
@@ -275,8 +498,26 @@ func (r *voiceRepository) onVoiceServerUpdate(_ Session, event *VoiceServerUpdat
 		r.mu.Unlock()
 
 		ch <- event
-	} else {
-		r.mu.Unlock()
+		return
+	}
+	voice, exists := r.active[gid]
+	r.mu.Unlock()
+
+	// a missing endpoint means Discord is allocating a new voice server, which is sent in a later update
+	if exists && event.Endpoint != "" {
+		r.c.Logger().Info("voice server changed for guild ", gid, ", reconnecting to ", event.Endpoint)
+		voice.Lock()
+		voice.endpoint = event.Endpoint
+		voice.Unlock()
+		voice.ws.Reconnect(voiceEndpoint(event.Endpoint), event.Token)
+	}
+}
+
+func (r *voiceRepository) removeActive(v *voiceImpl) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.active[v.guildID] == v {
+		delete(r.active, v.guildID)
 	}
 }
 
@@ -285,6 +526,12 @@ func (v *voiceImpl) StartSpeaking() error {
 }
 
 func (v *voiceImpl) StopSpeaking() error {
+	// the frames in the jitter buffer and the trailing silence must be sent first
+	select {
+	case <-v.pacer.drained():
+	case <-v.close:
+	case <-time.After(v.pacer.drainTimeout()):
+	}
 	return v.speakingImpl(false)
 }
 
@@ -296,9 +543,11 @@ func (v *voiceImpl) speakingImpl(b bool) error {
 		return errors.New("attempting to interact with a closed voice connection")
 	}
 
+	_, ssrc, _ := v.transport()
+	v.speaking.Store(b)
 	return v.ws.Emit(cmd.VoiceSpeaking, &voiceSpeakingData{
 		Speaking: b,
-		SSRC:     v.ssrc,
+		SSRC:     ssrc,
 	})
 }
 
@@ -306,8 +555,11 @@ func (v *voiceImpl) SendOpusFrame(data []byte) error {
 	if !v.ready.Load() {
 		return errors.New("attempting to send to a closed voice connection")
 	}
-	v.send <- data
-	return nil
+	return v.pacer.queue(data)
+}
+
+func (v *voiceImpl) SendStats() VoiceSendStats {
+	return v.sendStats.snapshot()
 }
 
 func (v *voiceImpl) SendDCA(r io.Reader) error {
@@ -315,21 +567,18 @@ func (v *voiceImpl) SendDCA(r io.Reader) error {
 		return errors.New("attempting to send to a closed voice connection")
 	}
 
-	var sampleSize uint16
+	frames := &dcaReader{r: r}
 	for {
-		if err := binary.Read(r, binary.LittleEndian, &sampleSize); err != nil {
-			if err == io.EOF {
-				return nil
-			}
+		frame, err := frames.ReadOpusFrame()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
 			return err
 		}
 
-		buf := make([]byte, sampleSize)
-		if _, err := io.ReadFull(r, buf); err != nil {
-			panic(err)
+		if err = v.SendOpusFrame(frame); err != nil {
+			return err
 		}
-
-		v.send <- buf
 	}
 }
 
@@ -338,23 +587,93 @@ func (v *voiceImpl) MoveTo(channelID Snowflake) error {
 		return errors.New("channelID must be set to move to a voice channel")
 	}
 
+	v.Lock()
+	selfMute, selfDeaf := v.selfMute, v.selfDeaf
+	v.Unlock()
+	return v.updateVoiceState(channelID, selfMute, selfDeaf)
+}
+
+func (v *voiceImpl) SetSelfMute(mute bool) error {
+	v.Lock()
+	channelID, selfDeaf := v.channelID, v.selfDeaf
+	v.Unlock()
+	return v.updateVoiceState(channelID, mute, selfDeaf)
+}
+
+func (v *voiceImpl) SetSelfDeaf(deaf bool) error {
+	v.Lock()
+	channelID, selfMute := v.channelID, v.selfMute
+	v.Unlock()
+	return v.updateVoiceState(channelID, selfMute, deaf)
+}
+
+// updateVoiceState tells Discord which channel the bot is in, and whether it is muted or deafened.
+func (v *voiceImpl) updateVoiceState(channelID Snowflake, selfMute, selfDeaf bool) error {
 	v.Lock()
 	defer v.Unlock()
 
 	if !v.ready.Load() {
-		return errors.New("attempting to move in a closed Voice Connection")
+		return errors.New("attempting to update a closed Voice Connection")
+	}
+	if channelID == v.channelID && selfMute == v.selfMute && selfDeaf == v.selfDeaf {
+		return nil
 	}
 
-	_, _ = v.c.Gateway().Dispatch(UpdateVoiceState, &UpdateVoiceStatePayload{
+	_, err := v.c.Gateway().Dispatch(UpdateVoiceState, &UpdateVoiceStatePayload{
 		GuildID:   v.guildID,
 		ChannelID: channelID,
-		SelfDeaf:  true, //false,
-		SelfMute:  false,
+		SelfDeaf:  selfDeaf,
+		SelfMute:  selfMute,
 	})
+	if err != nil {
+		return err
+	}
 
+	v.channelID, v.selfMute, v.selfDeaf = channelID, selfMute, selfDeaf
 	return nil
 }
 
+func (v *voiceImpl) SelfMute() bool {
+	v.Lock()
+	defer v.Unlock()
+	return v.selfMute
+}
+
+func (v *voiceImpl) SelfDeaf() bool {
+	v.Lock()
+	defer v.Unlock()
+	return v.selfDeaf
+}
+
+func (v *voiceImpl) GuildID() Snowflake {
+	return v.guildID
+}
+
+func (v *voiceImpl) ChannelID() Snowflake {
+	v.Lock()
+	defer v.Unlock()
+	return v.channelID
+}
+
+func (v *voiceImpl) Endpoint() string {
+	v.Lock()
+	defer v.Unlock()
+	return v.endpoint
+}
+
+func (v *voiceImpl) SSRC() uint32 {
+	_, ssrc, _ := v.transport()
+	return ssrc
+}
+
+func (v *voiceImpl) HeartbeatLatency() (time.Duration, error) {
+	return v.ws.HeartbeatLatency()
+}
+
+func (v *voiceImpl) Done() <-chan struct{} {
+	return v.close
+}
+
 func (v *voiceImpl) watcherDiscordCloseEvt() {
 	for {
 		var open bool
@@ -377,15 +696,11 @@ func (v *voiceImpl) watcherDiscordCloseEvt() {
 	v.ready.Store(false)
 
 	close(v.close)
-	// clear send channel
-	select {
-	case <-v.send:
-	default:
-	}
 
-	_ = v.udp.Close()
+	udp, _, _ := v.transport()
+	_ = udp.Close()
 	_ = v.ws.Disconnect()
-	close(v.send)
+	v.c.voiceRepository.removeActive(v)
 
 	//for range v.ws.Receive() {} // drain
 
@@ -399,21 +714,23 @@ func (v *voiceImpl) Close() (err error) {
 	if !v.ready.Load() {
 		return errors.New("attempting to close a closed Voice Connection")
 	}
+	// the send and receive loops stop once the connection is no longer ready, instead of reporting the closed
+	// UDP connection as an error
+	v.ready.Store(false)
 
 	defer func() {
 		close(v.close)
-		// clear send channel
-		select {
-		case <-v.send:
-		default:
-		}
-		close(v.send)
+		v.c.voiceRepository.removeActive(v)
 	}()
 
+	udp, _, _ := v.transport()
+
 	// if discord have already closed the connection
 	// there is no need to send out a bunch of events
 	if v.ws.IsDisconnected() {
-		return v.udp.Close()
+		// stops any ongoing reconnect attempts
+		_ = v.ws.Disconnect()
+		return udp.Close()
 	}
 
 	// Tell Discord we want to disconnect from channel/guild
@@ -424,7 +741,7 @@ func (v *voiceImpl) Close() (err error) {
 		SelfMute:  true,
 	})
 
-	err1 := v.udp.Close()
+	err1 := udp.Close()
 	err2 := v.ws.Disconnect()
 
 	if err1 != nil || err2 != nil {
@@ -449,50 +766,17 @@ type voiceSpeakingData struct {
 }
 
 func (v *voiceImpl) opusSendLoop() {
-	// https://discord.com/developers/docs/topics/voice-connections#encrypting-and-sending-voice
-	header := make([]byte, 12)
-	header[0] = 0x80
-	header[1] = 0x78
-	binary.BigEndian.PutUint32(header[8:12], v.ssrc)
-
-	var (
-		sequence  uint16
-		timestamp uint32
-		nonce     [24]byte
-
-		msg  []byte
-		open bool
-	)
-
-	frequency := time.NewTicker(time.Millisecond * 20) // 50 sends per sec, 960 samples each at 48kHz
-	defer frequency.Stop()
-
-	for {
-		select {
-		case msg, open = <-v.send:
-			if !open {
-				return
-			}
-		case <-v.close:
-			return
-		}
-
-		binary.BigEndian.PutUint16(header[2:4], sequence)
-		sequence++
-
-		binary.BigEndian.PutUint32(header[4:8], timestamp)
-		timestamp += 960 // samples
-
-		copy(nonce[:], header)
-
-		toSend := secretbox.Seal(header, msg, &nonce, &v.secretKey)
-		select {
-		case <-frequency.C:
-		case <-v.close:
-			return
-		}
+	v.pacer.run()
+}
 
-		_, _ = v.udp.Write(toSend)
-		// err on udp write? hahahahahah... hahah.. good joke.
+func (v *voiceImpl) writeOpusFrame(header, frame []byte) bool {
+	// frames are dropped while reconnecting, such that callers can keep sending at the same pace
+	if v.ws.State() != VoiceConnectionStateConnected {
+		return false
 	}
+
+	udp, ssrc, crypto := v.transport()
+	binary.BigEndian.PutUint32(header[8:12], ssrc)
+	_, err := udp.Write(crypto.seal(header, frame))
+	return err == nil
 }
@@ -0,0 +1,130 @@
+// +build !integration
+
+package disgord
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord/internal/gateway"
+	"github.com/andersfylling/disgord/internal/logger"
+	"go.uber.org/atomic"
+)
+
+func TestVoiceRepository_active(t *testing.T) {
+	client := newPagingClient(t, func(_ *http.Request) interface{} {
+		return nil
+	})
+	client.botID = 1
+
+	voice := &voiceImpl{guildID: 2, channelID: 3, selfDeaf: true, c: client}
+	voice.ready.Store(true)
+	client.voiceRepository.active[2] = voice
+
+	if client.ActiveVoiceConnection(2) != voice {
+		t.Fatal("expected the active voice connection to be registered")
+	}
+	if client.ActiveVoiceConnection(4) != nil {
+		t.Error("expected no voice connection for another guild")
+	}
+
+	// connecting to the same channel reuses the connection without any requests to Discord
+	reused, err := client.voiceConnectOptions(2, 3, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reused != voice {
+		t.Error("expected the active voice connection to be reused")
+	}
+
+	// without a gateway connection the voice state can not be changed
+	if err = voice.SetSelfMute(true); err == nil {
+		t.Error("expected an error when the voice state update could not be sent")
+	}
+	if voice.SelfMute() {
+		t.Error("the mute state should not change when the update failed")
+	}
+
+	// the bot was moved and undeafened by a moderator
+	client.voiceRepository.onVoiceStateUpdate(nil, &VoiceStateUpdate{VoiceState: &VoiceState{
+		GuildID:   2,
+		ChannelID: 5,
+		UserID:    1,
+	}})
+	if voice.ChannelID() != 5 || voice.SelfDeaf() {
+		t.Errorf("expected the voice state to be updated, got channel %d and deaf %t", voice.ChannelID(), voice.SelfDeaf())
+	}
+
+	client.voiceRepository.removeActive(voice)
+	if client.ActiveVoiceConnection(2) != nil {
+		t.Error("expected the voice connection to be removed")
+	}
+}
+
+type errorRecorder struct {
+	logger.Empty
+	errors atomic.Int32
+}
+
+func (l *errorRecorder) Error(_ ...interface{}) {
+	l.errors.Inc()
+}
+
+func TestVoiceImpl_Close(t *testing.T) {
+	log := &errorRecorder{}
+	c := New(Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		Cache:        &CacheNop{},
+		Logger:       log,
+	})
+	ws, err := gateway.NewVoiceClient(&gateway.VoiceConfig{
+		Logger:         log,
+		SystemShutdown: make(chan interface{}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	udp, server := net.Pipe()
+	defer server.Close()
+	v := &voiceImpl{
+		guildID:     2,
+		c:           c,
+		ws:          ws,
+		udp:         udp,
+		udpReplaced: make(chan struct{}),
+		receive:     make(chan *VoicePacket, 1),
+		close:       make(chan struct{}),
+		ssrcs:       make(map[uint32]Snowflake),
+	}
+	v.pacer = newOpusPacer(5, v.close, v.writeOpusFrame, &v.sendStats)
+	v.ready.Store(true)
+	go v.opusReceiveLoop()
+
+	if err = v.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-v.receive:
+	case <-time.After(time.Second):
+		t.Fatal("expected the receive loop to stop")
+	}
+	if errs := log.errors.Load(); errs != 0 {
+		t.Errorf("expected no errors to be logged on close, got %d", errs)
+	}
+
+	for i := 0; i < 20; i++ {
+		if err = v.SendOpusFrame([]byte{1}); err == nil {
+			t.Fatal("expected frames to be rejected after close")
+		}
+		if err = v.pacer.queue([]byte{1}); err == nil {
+			t.Fatal("expected the pacer to reject frames after close")
+		}
+	}
+	if err = v.Close(); err == nil {
+		t.Error("expected an error when closing twice")
+	}
+}
@@ -0,0 +1,181 @@
+package disgord
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// Voice encryption modes, see https://discord.com/developers/docs/topics/voice-connections#transport-encryption-modes
+const (
+	voiceModeAEADAES256GCMRTPSize         = "aead_aes256_gcm_rtpsize"
+	voiceModeAEADXChaCha20Poly1305RTPSize = "aead_xchacha20_poly1305_rtpsize"
+	voiceModeXSalsa20Poly1305Lite         = "xsalsa20_poly1305_lite"
+	voiceModeXSalsa20Poly1305Suffix       = "xsalsa20_poly1305_suffix"
+	voiceModeXSalsa20Poly1305             = "xsalsa20_poly1305"
+)
+
+// voiceModePreference lists the supported encryption modes, from the most preferred. Discord is
+// deprecating the xsalsa20 modes in favour of the AEAD modes.
+var voiceModePreference = []string{
+	voiceModeAEADAES256GCMRTPSize,
+	voiceModeAEADXChaCha20Poly1305RTPSize,
+	voiceModeXSalsa20Poly1305Lite,
+	voiceModeXSalsa20Poly1305Suffix,
+	voiceModeXSalsa20Poly1305,
+}
+
+// selectVoiceMode returns the most preferred encryption mode offered by Discord.
+func selectVoiceMode(offered []string) (string, error) {
+	for _, mode := range voiceModePreference {
+		for i := range offered {
+			if offered[i] == mode {
+				return mode, nil
+			}
+		}
+	}
+	return "", errors.New("discord did not offer any supported voice encryption mode")
+}
+
+// voiceCrypto encrypts and decrypts the payload of RTP packets. seal is only called by the send loop.
+type voiceCrypto interface {
+	// seal returns the header followed by the encrypted opus frame, and any nonce required by the mode.
+	seal(header, opus []byte) []byte
+
+	// open decrypts the packet, where the first unencrypted bytes are the RTP header.
+	open(packet []byte, unencrypted int) ([]byte, error)
+
+	// rtpSize is true when the RTP header extension header is left unencrypted, as in the rtpsize modes.
+	rtpSize() bool
+}
+
+func newVoiceCrypto(mode string, secretKey [32]byte) (voiceCrypto, error) {
+	switch mode {
+	case voiceModeXSalsa20Poly1305:
+		return &xsalsa20Crypto{key: secretKey}, nil
+	case voiceModeXSalsa20Poly1305Suffix:
+		return &xsalsa20Crypto{key: secretKey, suffix: true}, nil
+	case voiceModeXSalsa20Poly1305Lite:
+		return &xsalsa20Crypto{key: secretKey, lite: true}, nil
+	case voiceModeAEADAES256GCMRTPSize:
+		block, err := aes.NewCipher(secretKey[:])
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		return &aeadCrypto{aead: aead}, nil
+	case voiceModeAEADXChaCha20Poly1305RTPSize:
+		aead, err := chacha20poly1305.NewX(secretKey[:])
+		if err != nil {
+			return nil, err
+		}
+		return &aeadCrypto{aead: aead}, nil
+	}
+	return nil, errors.New("unsupported voice encryption mode " + mode)
+}
+
+// xsalsa20Crypto implements the xsalsa20_poly1305 modes. The nonce is either the RTP header, 24 random
+// bytes appended to the packet (suffix), or an incrementing 4 byte counter appended to the packet (lite).
+type xsalsa20Crypto struct {
+	key    [32]byte
+	suffix bool
+	lite   bool
+	nonce  uint32
+}
+
+func (c *xsalsa20Crypto) rtpSize() bool {
+	return false
+}
+
+func (c *xsalsa20Crypto) seal(header, opus []byte) []byte {
+	var nonce [24]byte
+	switch {
+	case c.suffix:
+		_, _ = rand.Read(nonce[:])
+	case c.lite:
+		c.nonce++
+		binary.BigEndian.PutUint32(nonce[:4], c.nonce)
+	default:
+		copy(nonce[:], header[:rtpHeaderSize])
+	}
+
+	packet := secretbox.Seal(append([]byte{}, header...), opus, &nonce, &c.key)
+	switch {
+	case c.suffix:
+		packet = append(packet, nonce[:]...)
+	case c.lite:
+		packet = append(packet, nonce[:4]...)
+	}
+	return packet
+}
+
+func (c *xsalsa20Crypto) open(packet []byte, unencrypted int) ([]byte, error) {
+	var nonce [24]byte
+	end := len(packet)
+	switch {
+	case c.suffix:
+		end -= len(nonce)
+	case c.lite:
+		end -= 4
+	}
+	if end < unencrypted {
+		return nil, errors.New("voice packet is too short for the encryption nonce")
+	}
+
+	if end == len(packet) {
+		copy(nonce[:], packet[:rtpHeaderSize])
+	} else {
+		copy(nonce[:], packet[end:])
+	}
+
+	data, ok := secretbox.Open(nil, packet[unencrypted:end], &nonce, &c.key)
+	if !ok {
+		return nil, errors.New("unable to decrypt voice packet")
+	}
+	return data, nil
+}
+
+// aeadCrypto implements the AEAD rtpsize modes, where the unencrypted part of the RTP header is authenticated
+// and an incrementing 4 byte counter is appended to the packet as the nonce.
+type aeadCrypto struct {
+	aead  cipher.AEAD
+	nonce uint32
+}
+
+func (c *aeadCrypto) rtpSize() bool {
+	return true
+}
+
+func (c *aeadCrypto) seal(header, opus []byte) []byte {
+	c.nonce++
+	nonce := make([]byte, c.aead.NonceSize())
+	binary.BigEndian.PutUint32(nonce, c.nonce)
+
+	packet := make([]byte, len(header), len(header)+len(opus)+c.aead.Overhead()+4)
+	copy(packet, header)
+	packet = c.aead.Seal(packet, nonce, opus, header)
+	return append(packet, nonce[:4]...)
+}
+
+func (c *aeadCrypto) open(packet []byte, unencrypted int) ([]byte, error) {
+	end := len(packet) - 4
+	if end < unencrypted {
+		return nil, errors.New("voice packet is too short for the encryption nonce")
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	copy(nonce, packet[end:])
+
+	data, err := c.aead.Open(nil, nonce, packet[unencrypted:end], packet[:unencrypted])
+	if err != nil {
+		return nil, errors.New("unable to decrypt voice packet")
+	}
+	return data, nil
+}
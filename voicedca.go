@@ -0,0 +1,49 @@
+package disgord
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// OpusFrameReader reads one Opus frame at a time from an audio container. io.EOF is returned once the
+// stream has ended.
+type OpusFrameReader interface {
+	ReadOpusFrame() ([]byte, error)
+}
+
+// NewDCAReader returns an OpusFrameReader for a DCA encoded stream, where every frame is prefixed by its
+// size as a little endian uint16. The stream is closed when the reader is closed, if it is an io.Closer.
+func NewDCAReader(r io.Reader) OpusFrameReader {
+	return &dcaReader{r: r}
+}
+
+type dcaReader struct {
+	r io.Reader
+}
+
+var _ OpusFrameReader = (*dcaReader)(nil)
+var _ io.Closer = (*dcaReader)(nil)
+
+func (d *dcaReader) ReadOpusFrame() ([]byte, error) {
+	var size uint16
+	if err := binary.Read(d.r, binary.LittleEndian, &size); err != nil {
+		return nil, err
+	}
+
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(d.r, frame); err != nil {
+		if err == io.EOF {
+			// the stream ended after the frame size
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return frame, nil
+}
+
+func (d *dcaReader) Close() error {
+	if closer, ok := d.r.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
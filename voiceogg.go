@@ -0,0 +1,390 @@
+package disgord
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand"
+)
+
+// Ogg Opus, see https://tools.ietf.org/html/rfc3533 and https://tools.ietf.org/html/rfc7845
+const (
+	oggHeaderSize   = 27
+	oggMaxSegments  = 255
+	oggPagePackets  = 50 // one second of 20ms frames
+	oggContinued    = 0x01
+	oggBeginStream  = 0x02
+	oggEndStream    = 0x04
+	opusSampleRate  = 48000
+	opusFrameLength = 960 // samples in a 20ms frame
+)
+
+var (
+	oggCapturePattern = []byte("OggS")
+	opusHeadMagic     = []byte("OpusHead")
+	opusTagsMagic     = []byte("OpusTags")
+)
+
+// oggCRCTable is the lookup table for the CRC-32 used by Ogg, which uses the polynomial 0x04c11db7
+// without reflection.
+var oggCRCTable = func() (table [256]uint32) {
+	for i := range table {
+		r := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if r&0x80000000 != 0 {
+				r = r<<1 ^ 0x04c11db7
+			} else {
+				r <<= 1
+			}
+		}
+		table[i] = r
+	}
+	return table
+}()
+
+func oggCRC(crc uint32, data []byte) uint32 {
+	for _, b := range data {
+		crc = crc<<8 ^ oggCRCTable[byte(crc>>24)^b]
+	}
+	return crc
+}
+
+// opusPacketSamples returns the number of 48kHz samples in an Opus packet, from its TOC byte.
+// See https://tools.ietf.org/html/rfc6716#section-3.1
+func opusPacketSamples(packet []byte) int {
+	if len(packet) == 0 {
+		return 0
+	}
+
+	var frameSize int
+	config := packet[0] >> 3
+	switch {
+	case config < 12: // SILK: 10, 20, 40 or 60ms
+		frameSize = []int{480, 960, 1920, 2880}[config%4]
+	case config < 16: // hybrid: 10 or 20ms
+		frameSize = []int{480, 960}[config%2]
+	default: // CELT: 2.5, 5, 10 or 20ms
+		frameSize = []int{120, 240, 480, 960}[config%4]
+	}
+
+	switch packet[0] & 0x03 {
+	case 0:
+		return frameSize
+	case 1, 2:
+		return 2 * frameSize
+	default:
+		if len(packet) < 2 {
+			return 0
+		}
+		return int(packet[1]&0x3F) * frameSize
+	}
+}
+
+// NewOggReader returns an OpusFrameReader for an Ogg Opus stream, such as the .opus files created by opusenc
+// or ffmpeg. The audio must be encoded as 48kHz stereo with 20ms frames, as frames are sent every 20ms. Only
+// the first logical stream is read, and chained streams are read one after another. The stream is closed when
+// the reader is closed, if it is an io.Closer.
+func NewOggReader(r io.Reader) OpusFrameReader {
+	return &oggReader{r: r}
+}
+
+type oggReader struct {
+	r io.Reader
+
+	serial  uint32
+	started bool
+	ended   bool
+	headers int // the number of Opus header packets read in the current stream
+
+	packets [][]byte
+	partial []byte // a packet that continues on the next page
+}
+
+var _ OpusFrameReader = (*oggReader)(nil)
+var _ io.Closer = (*oggReader)(nil)
+
+func (o *oggReader) ReadOpusFrame() ([]byte, error) {
+	for {
+		if len(o.packets) == 0 {
+			if err := o.readPage(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		packet := o.packets[0]
+		o.packets = o.packets[1:]
+		switch o.headers {
+		case 0:
+			if !bytes.HasPrefix(packet, opusHeadMagic) {
+				return nil, errors.New("ogg stream does not contain Opus audio")
+			}
+			if len(packet) < 19 || packet[8]>>4 != 0 {
+				return nil, errors.New("unsupported Opus header version")
+			}
+		case 1:
+			if !bytes.HasPrefix(packet, opusTagsMagic) {
+				return nil, errors.New("ogg stream is missing the Opus comment header")
+			}
+		default:
+			return packet, nil
+		}
+		o.headers++
+	}
+}
+
+// readPage reads the next Ogg page, and queues the packets that are completed on it.
+func (o *oggReader) readPage() error {
+	header := make([]byte, oggHeaderSize)
+	if _, err := io.ReadFull(o.r, header); err != nil {
+		return err
+	}
+	if !bytes.Equal(header[:4], oggCapturePattern) {
+		return errors.New("invalid ogg page capture pattern")
+	}
+	if header[4] != 0 {
+		return errors.New("unsupported ogg version")
+	}
+
+	segments := make([]byte, header[26])
+	if _, err := io.ReadFull(o.r, segments); err != nil {
+		return unexpectedEOF(err)
+	}
+	var size int
+	for _, segment := range segments {
+		size += int(segment)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(o.r, body); err != nil {
+		return unexpectedEOF(err)
+	}
+
+	crc := binary.LittleEndian.Uint32(header[22:26])
+	binary.LittleEndian.PutUint32(header[22:26], 0)
+	if oggCRC(oggCRC(oggCRC(0, header), segments), body) != crc {
+		return errors.New("ogg page checksum mismatch")
+	}
+
+	headerType := header[5]
+	serial := binary.LittleEndian.Uint32(header[14:18])
+	if headerType&oggBeginStream != 0 && (!o.started || o.ended) {
+		// the first stream, or the next stream of a chained file
+		o.serial, o.started, o.ended, o.headers, o.partial = serial, true, false, 0, nil
+	}
+	if !o.started || serial != o.serial {
+		// pages of other logical streams are ignored
+		return nil
+	}
+	if headerType&oggEndStream != 0 {
+		o.ended = true
+	}
+	if headerType&oggContinued == 0 {
+		o.partial = nil
+	}
+
+	for _, segment := range segments {
+		o.partial = append(o.partial, body[:segment]...)
+		body = body[segment:]
+		if segment < 255 {
+			o.packets = append(o.packets, o.partial)
+			o.partial = nil
+		}
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (o *oggReader) Close() error {
+	if closer, ok := o.r.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// OggWriter writes Opus frames as an Ogg Opus stream, which can be saved as a .opus file.
+type OggWriter struct {
+	w io.Writer
+
+	serial   uint32
+	sequence uint32
+	granule  uint64
+	packets  [][]byte
+	segments int
+
+	// next is the RTP timestamp expected for the next voice packet
+	next    uint32
+	started bool
+	closed  bool
+}
+
+// NewOggWriter writes the Opus headers of a stereo stream to w, and returns a writer for the audio.
+// Close must be called to end the stream.
+func NewOggWriter(w io.Writer) (*OggWriter, error) {
+	o := &OggWriter{
+		w:      w,
+		serial: rand.Uint32(),
+	}
+
+	head := make([]byte, 19)
+	copy(head, opusHeadMagic)
+	head[8] = 1 // version
+	head[9] = 2 // channels
+	binary.LittleEndian.PutUint32(head[12:16], opusSampleRate)
+	if err := o.writePage(oggBeginStream, 0, [][]byte{head}); err != nil {
+		return nil, err
+	}
+
+	vendor := []byte("disgord")
+	tags := make([]byte, 8+4+len(vendor)+4)
+	copy(tags, opusTagsMagic)
+	binary.LittleEndian.PutUint32(tags[8:12], uint32(len(vendor)))
+	copy(tags[12:], vendor)
+	if err := o.writePage(0, 0, [][]byte{tags}); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// WriteOpusFrame adds a single Opus packet to the stream.
+func (o *OggWriter) WriteOpusFrame(frame []byte) error {
+	if o.closed {
+		return errors.New("ogg writer is closed")
+	}
+
+	segments := len(frame)/255 + 1
+	if o.segments+segments > oggMaxSegments || len(o.packets) >= oggPagePackets {
+		if err := o.flush(0); err != nil {
+			return err
+		}
+	}
+	o.packets = append(o.packets, frame)
+	o.segments += segments
+	o.granule += uint64(opusPacketSamples(frame))
+	return nil
+}
+
+// WriteVoicePacket adds a received voice packet to the stream. Silence is inserted when the RTP timestamp
+// shows that the speaker stopped sending audio, such that the stream keeps the timing of the conversation.
+// Packets that arrive after a later packet has been written are dropped.
+func (o *OggWriter) WriteVoicePacket(packet *VoicePacket) error {
+	if o.started {
+		gap := int32(packet.Timestamp - o.next)
+		if gap < 0 {
+			return nil
+		}
+		for i := 0; i < int(gap)/opusFrameLength; i++ {
+			if err := o.WriteOpusFrame(opusSilenceFrame); err != nil {
+				return err
+			}
+		}
+	}
+	o.started = true
+	o.next = packet.Timestamp + uint32(opusPacketSamples(packet.Opus))
+	return o.WriteOpusFrame(packet.Opus)
+}
+
+// Close writes the remaining frames and ends the stream. The underlying writer is not closed.
+func (o *OggWriter) Close() error {
+	if o.closed {
+		return errors.New("ogg writer is closed")
+	}
+	o.closed = true
+	return o.flush(oggEndStream)
+}
+
+func (o *OggWriter) flush(headerType byte) error {
+	packets := o.packets
+	o.packets, o.segments = nil, 0
+	return o.writePage(headerType, o.granule, packets)
+}
+
+func (o *OggWriter) writePage(headerType byte, granule uint64, packets [][]byte) error {
+	var segments []byte
+	var body []byte
+	for _, packet := range packets {
+		for size := len(packet); size >= 255; size -= 255 {
+			segments = append(segments, 255)
+		}
+		segments = append(segments, byte(len(packet)%255))
+		body = append(body, packet...)
+	}
+
+	page := make([]byte, oggHeaderSize, oggHeaderSize+len(segments)+len(body))
+	copy(page, oggCapturePattern)
+	page[5] = headerType
+	binary.LittleEndian.PutUint64(page[6:14], granule)
+	binary.LittleEndian.PutUint32(page[14:18], o.serial)
+	binary.LittleEndian.PutUint32(page[18:22], o.sequence)
+	page[26] = byte(len(segments))
+	page = append(append(page, segments...), body...)
+	binary.LittleEndian.PutUint32(page[22:26], oggCRC(0, page))
+	o.sequence++
+
+	_, err := o.w.Write(page)
+	return err
+}
+
+// RecordVoice writes the received audio of every speaker to a separate Ogg Opus stream, until the packets
+// channel is closed or the context is cancelled. create is called once for every new SSRC, and the returned
+// writer is closed when the recording ends. Packets are dropped until Discord has linked the SSRC to a user.
+//
+//	err := disgord.RecordVoice(ctx, voice.Receive(), func(userID disgord.Snowflake, ssrc uint32) (io.WriteCloser, error) {
+//		return os.Create(fmt.Sprintf("%d-%d.opus", userID, ssrc))
+//	})
+func RecordVoice(ctx context.Context, packets <-chan *VoicePacket, create func(userID Snowflake, ssrc uint32) (io.WriteCloser, error)) (err error) {
+	type speaker struct {
+		file io.WriteCloser
+		ogg  *OggWriter
+	}
+	speakers := make(map[uint32]*speaker)
+	defer func() {
+		for _, s := range speakers {
+			if closeErr := s.ogg.Close(); err == nil {
+				err = closeErr
+			}
+			if closeErr := s.file.Close(); err == nil {
+				err = closeErr
+			}
+		}
+	}()
+
+	for {
+		var packet *VoicePacket
+		var open bool
+		select {
+		case packet, open = <-packets:
+			if !open {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if packet.UserID.IsZero() {
+			continue
+		}
+
+		s, exists := speakers[packet.SSRC]
+		if !exists {
+			s = &speaker{}
+			if s.file, err = create(packet.UserID, packet.SSRC); err != nil {
+				return err
+			}
+			if s.ogg, err = NewOggWriter(s.file); err != nil {
+				_ = s.file.Close()
+				return err
+			}
+			speakers[packet.SSRC] = s
+		}
+		if err = s.ogg.WriteVoicePacket(packet); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// +build !integration
+
+package disgord
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// opusTestFrame returns a 20ms CELT frame of the given size.
+func opusTestFrame(size int, fill byte) []byte {
+	frame := bytes.Repeat([]byte{fill}, size)
+	frame[0] = 0xFC // config 31, stereo, a single frame
+	return frame
+}
+
+func readOggFrames(t *testing.T, r io.Reader) [][]byte {
+	t.Helper()
+	reader := NewOggReader(r)
+	var frames [][]byte
+	for {
+		frame, err := reader.ReadOpusFrame()
+		if err == io.EOF {
+			return frames
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, frame)
+	}
+}
+
+func TestOggWriter_roundTrip(t *testing.T) {
+	var frames [][]byte
+	for i := 0; i < 120; i++ {
+		frames = append(frames, opusTestFrame([]int{3, 160, 255, 510, 600}[i%5], byte(i)))
+	}
+
+	stream := &bytes.Buffer{}
+	writer, err := NewOggWriter(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, frame := range frames {
+		if err = writer.WriteOpusFrame(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the granule position of the last page is the number of samples in the stream
+	data := stream.Bytes()
+	last := bytes.LastIndex(data, oggCapturePattern)
+	if data[last+5] != oggEndStream {
+		t.Error("expected the last page to end the stream")
+	}
+	if granule := binary.LittleEndian.Uint64(data[last+6:]); granule != 120*opusFrameLength {
+		t.Errorf("expected granule position %d, got %d", 120*opusFrameLength, granule)
+	}
+
+	if got := readOggFrames(t, stream); !reflect.DeepEqual(got, frames) {
+		t.Errorf("expected %d frames to be read back, got %d", len(frames), len(got))
+	}
+}
+
+func TestOggReader(t *testing.T) {
+	headers := &bytes.Buffer{}
+	writer, err := NewOggWriter(headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("continued-packet", func(t *testing.T) {
+		frame := opusTestFrame(300, 7)
+
+		// the frame is split over two pages
+		stream := bytes.NewBuffer(append([]byte{}, headers.Bytes()...))
+		page := &bytes.Buffer{}
+		pages := &OggWriter{w: page, serial: writer.serial, sequence: writer.sequence}
+		if err := pages.writePage(0, 0, [][]byte{frame[:255]}); err != nil {
+			t.Fatal(err)
+		}
+		// remove the terminating zero length segment, such that the packet continues on the next page
+		raw := page.Bytes()
+		raw[26] = 1
+		raw = append(raw[:oggHeaderSize+1], raw[oggHeaderSize+2:]...)
+		binary.LittleEndian.PutUint32(raw[22:26], 0)
+		binary.LittleEndian.PutUint32(raw[22:26], oggCRC(0, raw))
+		stream.Write(raw)
+
+		page.Reset()
+		if err := pages.writePage(oggContinued|oggEndStream, opusFrameLength, [][]byte{frame[255:]}); err != nil {
+			t.Fatal(err)
+		}
+		stream.Write(page.Bytes())
+
+		if got := readOggFrames(t, stream); len(got) != 1 || !bytes.Equal(got[0], frame) {
+			t.Errorf("expected the continued frame to be read, got %d frames", len(got))
+		}
+	})
+	t.Run("checksum", func(t *testing.T) {
+		corrupt := append([]byte{}, headers.Bytes()...)
+		corrupt[len(corrupt)-1] ^= 0xFF
+		if _, err := NewOggReader(bytes.NewReader(corrupt)).ReadOpusFrame(); err == nil {
+			t.Error("expected a checksum error")
+		}
+	})
+	t.Run("not-opus", func(t *testing.T) {
+		stream := &bytes.Buffer{}
+		vorbis := &OggWriter{w: stream}
+		_ = vorbis.writePage(oggBeginStream, 0, [][]byte{[]byte("\x01vorbis")})
+		if _, err := NewOggReader(stream).ReadOpusFrame(); err == nil {
+			t.Error("expected an error for a stream without Opus audio")
+		}
+	})
+	t.Run("truncated", func(t *testing.T) {
+		truncated := headers.Bytes()[:headers.Len()-3]
+		if _, err := NewOggReader(bytes.NewReader(truncated)).ReadOpusFrame(); err != io.ErrUnexpectedEOF {
+			t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+		}
+	})
+}
+
+type nopWriteCloser struct {
+	*bytes.Buffer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestRecordVoice(t *testing.T) {
+	packets := make(chan *VoicePacket, 10)
+	packets <- &VoicePacket{SSRC: 1, UserID: 10, Timestamp: 0, Opus: opusTestFrame(10, 1)}
+	packets <- &VoicePacket{SSRC: 2, UserID: 0, Timestamp: 0, Opus: opusTestFrame(10, 9)}
+	packets <- &VoicePacket{SSRC: 2, UserID: 20, Timestamp: 5000, Opus: opusTestFrame(10, 2)}
+	packets <- &VoicePacket{SSRC: 1, UserID: 10, Timestamp: 3 * opusFrameLength, Opus: opusTestFrame(10, 3)}
+	packets <- &VoicePacket{SSRC: 1, UserID: 10, Timestamp: opusFrameLength, Opus: opusTestFrame(10, 4)}
+	close(packets)
+
+	files := make(map[Snowflake]*bytes.Buffer)
+	err := RecordVoice(context.Background(), packets, func(userID Snowflake, ssrc uint32) (io.WriteCloser, error) {
+		files[userID] = &bytes.Buffer{}
+		return nopWriteCloser{files[userID]}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 recordings, got %d", len(files))
+	}
+
+	// two frames of silence are inserted for the gap, and the late packet is dropped
+	expected := [][]byte{opusTestFrame(10, 1), opusSilenceFrame, opusSilenceFrame, opusTestFrame(10, 3)}
+	if got := readOggFrames(t, files[10]); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := readOggFrames(t, files[20]); len(got) != 1 || got[0][1] != 2 {
+		t.Errorf("expected a single frame for user 20, got %v", got)
+	}
+}
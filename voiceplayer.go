@@ -0,0 +1,282 @@
+package disgord
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Track is a single audio source in the queue of a Player.
+type Track struct {
+	// Name is not used by the player, and can be used to identify the track in the callbacks.
+	Name string
+
+	// Frames is read until io.EOF. If it implements io.Closer, it is closed once the track has ended.
+	Frames OpusFrameReader
+}
+
+// PlayerConfig holds the optional callbacks of a Player. The callbacks are called from the goroutine
+// that called Play, and should not block.
+type PlayerConfig struct {
+	OnTrackStart func(track *Track)
+
+	// OnTrackEnd is called when a track has been played to the end, was skipped or the player was stopped.
+	OnTrackEnd func(track *Track)
+
+	// OnTrackError is called when a track could not be read. The player continues with the next track,
+	// unless the error was caused by the voice connection.
+	OnTrackError func(track *Track, err error)
+}
+
+// Player plays a queue of tracks on a voice connection, and handles the speaking state of the bot.
+// The methods of Player are safe for concurrent use.
+type Player struct {
+	voice VoiceConnection
+	conf  PlayerConfig
+
+	mu      sync.Mutex
+	queue   []*Track
+	current *Track
+	playing bool
+	paused  bool
+	skipped bool
+	stopped bool
+
+	// signal is notified whenever the player is paused, resumed, skipped or stopped
+	signal chan struct{}
+
+	// speaking is only used by the goroutine that called Play
+	speaking bool
+}
+
+// NewPlayer creates a player for the given voice connection. The config can be nil.
+func NewPlayer(voice VoiceConnection, conf *PlayerConfig) *Player {
+	p := &Player{
+		voice:  voice,
+		signal: make(chan struct{}, 1),
+	}
+	if conf != nil {
+		p.conf = *conf
+	}
+	return p
+}
+
+// Enqueue adds the tracks to the end of the queue. Tracks added while the player is playing are played
+// once the tracks before them have ended.
+func (p *Player) Enqueue(tracks ...*Track) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queue = append(p.queue, tracks...)
+}
+
+// Queue returns the tracks that have not yet been played, excluding the current track.
+func (p *Player) Queue() []*Track {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*Track{}, p.queue...)
+}
+
+// Current returns the track that is currently playing, or nil.
+func (p *Player) Current() *Track {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.current
+}
+
+// Paused reports whether the player is paused.
+func (p *Player) Paused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+// Pause pauses the current track. Five frames of silence are sent before the bot stops speaking.
+func (p *Player) Pause() {
+	p.update(func() {
+		p.paused = true
+	})
+}
+
+// Resume continues a paused track.
+func (p *Player) Resume() {
+	p.update(func() {
+		p.paused = false
+	})
+}
+
+// Skip ends the current track and continues with the next track in the queue. A paused player stays paused.
+func (p *Player) Skip() {
+	p.update(func() {
+		if p.current != nil {
+			p.skipped = true
+		}
+	})
+}
+
+// Stop ends the current track and clears the queue, which causes Play to return.
+func (p *Player) Stop() {
+	p.update(func() {
+		p.queue = nil
+		if p.playing {
+			p.stopped = true
+		}
+	})
+}
+
+func (p *Player) update(change func()) {
+	p.mu.Lock()
+	change()
+	p.mu.Unlock()
+
+	select {
+	case p.signal <- struct{}{}:
+	default:
+	}
+}
+
+// Play plays the queued tracks, and blocks until the queue is empty, the player is stopped or the context is
+// cancelled. Tracks that are not yet played stay in the queue when the context is cancelled, such that Play
+// can be called again. Note that reading a frame from a track can not be interrupted.
+func (p *Player) Play(ctx context.Context) (err error) {
+	p.mu.Lock()
+	if p.playing {
+		p.mu.Unlock()
+		return errors.New("the player is already playing")
+	}
+	p.playing = true
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		p.playing = false
+		p.current = nil
+		p.skipped = false
+		p.stopped = false
+		p.mu.Unlock()
+
+		if p.speaking {
+			if stopErr := p.stopSpeaking(); err == nil {
+				err = stopErr
+			}
+		}
+	}()
+
+	for {
+		track := p.next()
+		if track == nil {
+			return nil
+		}
+		if err = p.play(ctx, track); err != nil {
+			return err
+		}
+	}
+}
+
+// next makes the first track of the queue the current track.
+func (p *Player) next() *Track {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current = nil
+	p.skipped = false
+	if p.stopped || len(p.queue) == 0 {
+		return nil
+	}
+
+	p.current = p.queue[0]
+	p.queue[0] = nil
+	p.queue = p.queue[1:]
+	return p.current
+}
+
+// play sends the frames of a track. Only errors that should stop the player are returned.
+func (p *Player) play(ctx context.Context, track *Track) error {
+	if closer, ok := track.Frames.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if p.conf.OnTrackStart != nil {
+		p.conf.OnTrackStart(track)
+	}
+
+	for {
+		if err := p.awaitResume(ctx); err != nil {
+			p.trackEnded(track)
+			return err
+		}
+
+		p.mu.Lock()
+		interrupted := p.skipped || p.stopped
+		p.mu.Unlock()
+		if interrupted {
+			p.trackEnded(track)
+			return nil
+		}
+
+		frame, err := track.Frames.ReadOpusFrame()
+		if err == io.EOF {
+			p.trackEnded(track)
+			return nil
+		} else if err != nil {
+			p.trackFailed(track, err)
+			return nil
+		}
+
+		if !p.speaking {
+			if err = p.voice.StartSpeaking(); err != nil {
+				p.trackFailed(track, err)
+				return err
+			}
+			p.speaking = true
+		}
+		if err = p.voice.SendOpusFrame(frame); err != nil {
+			p.trackFailed(track, err)
+			return err
+		}
+	}
+}
+
+// awaitResume blocks while the player is paused, and stops speaking during the pause.
+func (p *Player) awaitResume(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		p.mu.Lock()
+		paused := p.paused && !p.skipped && !p.stopped
+		p.mu.Unlock()
+		if !paused {
+			return nil
+		}
+
+		if p.speaking {
+			if err := p.stopSpeaking(); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-p.signal:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// stopSpeaking stops speaking once the voice connection has sent the queued frames and the trailing silence.
+func (p *Player) stopSpeaking() error {
+	p.speaking = false
+	return p.voice.StopSpeaking()
+}
+
+func (p *Player) trackEnded(track *Track) {
+	if p.conf.OnTrackEnd != nil {
+		p.conf.OnTrackEnd(track)
+	}
+}
+
+func (p *Player) trackFailed(track *Track, err error) {
+	if p.conf.OnTrackError != nil {
+		p.conf.OnTrackError(track, err)
+	}
+}
@@ -0,0 +1,205 @@
+// +build !integration
+
+package disgord
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// voiceRecorder records the speaking state and frames sent to a voice connection.
+type voiceRecorder struct {
+	VoiceConnection
+
+	mu      sync.Mutex
+	events  []string
+	onFrame func(frame []byte)
+}
+
+func (v *voiceRecorder) record(event string) {
+	v.mu.Lock()
+	v.events = append(v.events, event)
+	v.mu.Unlock()
+}
+
+func (v *voiceRecorder) recorded() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]string{}, v.events...)
+}
+
+func (v *voiceRecorder) StartSpeaking() error {
+	v.record("start")
+	return nil
+}
+
+func (v *voiceRecorder) StopSpeaking() error {
+	v.record("stop")
+	return nil
+}
+
+func (v *voiceRecorder) SendOpusFrame(frame []byte) error {
+	v.record(string(frame))
+	if v.onFrame != nil {
+		v.onFrame(frame)
+	}
+	return nil
+}
+
+func dcaStream(frames ...string) io.Reader {
+	buf := &bytes.Buffer{}
+	for _, frame := range frames {
+		_ = binary.Write(buf, binary.LittleEndian, uint16(len(frame)))
+		buf.WriteString(frame)
+	}
+	return buf
+}
+
+func TestDCAReader(t *testing.T) {
+	stream := dcaStream("a", "bc").(*bytes.Buffer)
+	stream.Write([]byte{5, 0, 'd'}) // truncated frame
+
+	r := NewDCAReader(stream)
+	for _, expected := range []string{"a", "bc"} {
+		frame, err := r.ReadOpusFrame()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(frame) != expected {
+			t.Errorf("expected frame %q, got %q", expected, frame)
+		}
+	}
+	if _, err := r.ReadOpusFrame(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF for a truncated frame, got %v", err)
+	}
+}
+
+func TestPlayer_Play(t *testing.T) {
+	voice := &voiceRecorder{}
+
+	var callbacks []string
+	player := NewPlayer(voice, &PlayerConfig{
+		OnTrackStart: func(track *Track) {
+			callbacks = append(callbacks, "start "+track.Name)
+		},
+		OnTrackEnd: func(track *Track) {
+			callbacks = append(callbacks, "end "+track.Name)
+		},
+		OnTrackError: func(track *Track, err error) {
+			callbacks = append(callbacks, "error "+track.Name)
+		},
+	})
+	player.Enqueue(
+		&Track{Name: "first", Frames: NewDCAReader(dcaStream("1", "2"))},
+		&Track{Name: "broken", Frames: NewDCAReader(bytes.NewReader([]byte{3, 0, 'x'}))},
+		&Track{Name: "second", Frames: NewDCAReader(dcaStream("3"))},
+	)
+	if err := player.Play(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"start", "1", "2", "3", "stop"}
+	if events := voice.recorded(); !reflect.DeepEqual(events, expected) {
+		t.Errorf("expected %v, got %v", expected, events)
+	}
+	expectedCallbacks := []string{"start first", "end first", "start broken", "error broken", "start second", "end second"}
+	if !reflect.DeepEqual(callbacks, expectedCallbacks) {
+		t.Errorf("expected callbacks %v, got %v", expectedCallbacks, callbacks)
+	}
+	if player.Current() != nil || len(player.Queue()) != 0 {
+		t.Error("expected the queue to be empty")
+	}
+}
+
+func TestPlayer_controls(t *testing.T) {
+	t.Run("pause", func(t *testing.T) {
+		voice := &voiceRecorder{}
+		player := NewPlayer(voice, nil)
+		resumed := make(chan struct{})
+		voice.onFrame = func(frame []byte) {
+			if string(frame) == "1" {
+				player.Pause()
+				go func() {
+					defer close(resumed)
+					// the player is paused once it has stopped speaking
+					for {
+						events := voice.recorded()
+						if events[len(events)-1] == "stop" {
+							break
+						}
+					}
+					player.Resume()
+				}()
+			}
+		}
+		player.Enqueue(&Track{Frames: NewDCAReader(dcaStream("1", "2"))})
+		if err := player.Play(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		<-resumed
+
+		expected := []string{"start", "1", "stop", "start", "2", "stop"}
+		if events := voice.recorded(); !reflect.DeepEqual(events, expected) {
+			t.Errorf("expected %v, got %v", expected, events)
+		}
+	})
+	t.Run("skip-and-stop", func(t *testing.T) {
+		voice := &voiceRecorder{}
+		player := NewPlayer(voice, nil)
+		voice.onFrame = func(frame []byte) {
+			switch string(frame) {
+			case "1":
+				player.Skip()
+			case "3":
+				player.Stop()
+			}
+		}
+		player.Enqueue(
+			&Track{Frames: NewDCAReader(dcaStream("1", "2"))},
+			&Track{Frames: NewDCAReader(dcaStream("3", "4"))},
+			&Track{Frames: NewDCAReader(dcaStream("5"))},
+		)
+		if err := player.Play(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []string{"start", "1", "3", "stop"}
+		if events := voice.recorded(); !reflect.DeepEqual(events, expected) {
+			t.Errorf("expected %v, got %v", expected, events)
+		}
+		if len(player.Queue()) != 0 {
+			t.Error("stop should clear the queue")
+		}
+	})
+	t.Run("cancel", func(t *testing.T) {
+		voice := &voiceRecorder{}
+		player := NewPlayer(voice, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		voice.onFrame = func(frame []byte) {
+			if string(frame) == "1" {
+				cancel()
+			}
+		}
+		player.Enqueue(
+			&Track{Frames: NewDCAReader(dcaStream("1", "2"))},
+			&Track{Frames: NewDCAReader(dcaStream("3"))},
+		)
+		if err := player.Play(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+
+		expected := []string{"start", "1", "stop"}
+		if events := voice.recorded(); !reflect.DeepEqual(events, expected) {
+			t.Errorf("expected %v, got %v", expected, events)
+		}
+		if len(player.Queue()) != 1 {
+			t.Error("the remaining tracks should stay in the queue")
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package disgord
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+
+	"github.com/andersfylling/disgord/internal/gateway"
+)
+
+// voiceReceiveBuffer is the number of packets that can wait in the receive channel before packets are
+// dropped, which is a few seconds of audio for a handful of speakers.
+const voiceReceiveBuffer = 512
+
+const (
+	rtpHeaderSize  = 12
+	rtpVersion     = 2
+	rtcpTypeFirst  = 200
+	rtcpTypeLast   = 204
+	maxVoicePacket = 1500
+)
+
+// VoicePacket is a decrypted Opus packet sent by another user in the voice channel.
+type VoicePacket struct {
+	SSRC uint32
+
+	// UserID is zero until Discord has linked the SSRC to a user, which happens once the user starts speaking.
+	UserID Snowflake
+
+	// Sequence and Timestamp are taken from the RTP header. The timestamp increases by 960 for every
+	// 20ms frame of 48kHz audio.
+	Sequence  uint16
+	Timestamp uint32
+
+	Opus []byte
+}
+
+// parseVoicePacket decrypts an RTP packet, and strips the RTP header extension and padding from the Opus data.
+func parseVoicePacket(packet []byte, crypto voiceCrypto) (*VoicePacket, error) {
+	if len(packet) < rtpHeaderSize {
+		return nil, errors.New("voice packet is too short for a RTP header")
+	}
+	if packet[0]>>6 != rtpVersion {
+		return nil, errors.New("voice packet is not a RTP packet")
+	}
+
+	csrcCount := int(packet[0] & 0x0F)
+	hasExtension := packet[0]&0x10 != 0
+	hasPadding := packet[0]&0x20 != 0
+
+	headerSize := rtpHeaderSize + 4*csrcCount
+	if len(packet) < headerSize {
+		return nil, errors.New("voice packet is too short for the RTP contributing sources")
+	}
+
+	// the rtpsize modes leave the extension header unencrypted, while the extension itself is encrypted
+	unencrypted := headerSize
+	var extensionSize int
+	if hasExtension && crypto.rtpSize() {
+		if len(packet) < headerSize+4 {
+			return nil, errors.New("voice packet is too short for the RTP header extension")
+		}
+		unencrypted += 4
+		extensionSize = 4 * int(binary.BigEndian.Uint16(packet[headerSize+2:headerSize+4]))
+	}
+
+	data, err := crypto.open(packet, unencrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasPadding {
+		if len(data) == 0 || int(data[len(data)-1]) > len(data) {
+			return nil, errors.New("voice packet has invalid RTP padding")
+		}
+		data = data[:len(data)-int(data[len(data)-1])]
+	}
+
+	if hasExtension && !crypto.rtpSize() {
+		if len(data) < 4 {
+			return nil, errors.New("voice packet is too short for the RTP header extension")
+		}
+		extensionSize = 4 + 4*int(binary.BigEndian.Uint16(data[2:4]))
+	}
+	if hasExtension {
+		if len(data) < extensionSize {
+			return nil, errors.New("voice packet is too short for the RTP header extension")
+		}
+		data = data[extensionSize:]
+	}
+
+	return &VoicePacket{
+		SSRC:      binary.BigEndian.Uint32(packet[8:12]),
+		Sequence:  binary.BigEndian.Uint16(packet[2:4]),
+		Timestamp: binary.BigEndian.Uint32(packet[4:8]),
+		Opus:      data,
+	}, nil
+}
+
+func (v *voiceImpl) Receive() <-chan *VoicePacket {
+	return v.receive
+}
+
+func (v *voiceImpl) onSpeaking(evt *gateway.VoiceSpeaking) {
+	v.ssrcMu.Lock()
+	v.ssrcs[evt.SSRC] = evt.UserID
+	v.ssrcMu.Unlock()
+
+	v.c.emitDisgordEvent(EvtVoiceSpeaking, v.guildID, &VoiceSpeaking{
+		GuildID:  v.guildID,
+		UserID:   evt.UserID,
+		SSRC:     evt.SSRC,
+		Speaking: evt.Speaking,
+	})
+}
+
+func (v *voiceImpl) onClientDisconnect(evt *gateway.VoiceClientDisconnect) {
+	var audioSSRC uint32
+	v.ssrcMu.Lock()
+	for ssrc, userID := range v.ssrcs {
+		if userID == evt.UserID {
+			delete(v.ssrcs, ssrc)
+			audioSSRC = ssrc
+		}
+	}
+	v.ssrcMu.Unlock()
+
+	v.c.emitDisgordEvent(EvtVoiceClientDisconnect, v.guildID, &VoiceClientDisconnect{
+		GuildID: v.guildID,
+		UserID:  evt.UserID,
+		SSRC:    audioSSRC,
+	})
+}
+
+func (v *voiceImpl) opusReceiveLoop() {
+	defer close(v.receive)
+
+	buffer := make([]byte, maxVoicePacket)
+	for {
+		v.udpMu.RLock()
+		udp, ssrc, crypto, replaced := v.udp, v.ssrc, v.crypto, v.udpReplaced
+		v.udpMu.RUnlock()
+
+		n, err := udp.Read(buffer)
+		if err != nil {
+			if !v.ready.Load() {
+				return
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+
+			// the UDP connection is closed when it is replaced after the voice server changed
+			select {
+			case <-replaced:
+				continue
+			default:
+			}
+
+			v.c.Logger().Error("voice receive: ", err)
+			select {
+			case <-replaced:
+			case <-v.close:
+				return
+			}
+			continue
+		}
+
+		// RTCP packets are sent on the same socket
+		if n >= 2 && buffer[1] >= rtcpTypeFirst && buffer[1] <= rtcpTypeLast {
+			continue
+		}
+
+		packet, err := parseVoicePacket(buffer[:n], crypto)
+		if err != nil {
+			v.c.Logger().Debug("voice receive: ", err)
+			continue
+		}
+		if packet.SSRC == ssrc {
+			continue
+		}
+
+		v.ssrcMu.RLock()
+		packet.UserID = v.ssrcs[packet.SSRC]
+		v.ssrcMu.RUnlock()
+
+		select {
+		case v.receive <- packet:
+		default:
+			// the caller is not keeping up, so the packet is dropped rather than blocking the socket
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// +build !integration
+
+package disgord
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/andersfylling/disgord/internal/gateway"
+)
+
+func TestParseVoicePacket(t *testing.T) {
+	opus := []byte{0xF8, 0xFF, 0xFE, 0x01}
+
+	header := make([]byte, rtpHeaderSize)
+	header[0] = 0x80
+	header[1] = 0x78
+	binary.BigEndian.PutUint16(header[2:4], 7)
+	binary.BigEndian.PutUint32(header[4:8], 960)
+	binary.BigEndian.PutUint32(header[8:12], 42)
+
+	for _, mode := range voiceModePreference {
+		t.Run(mode, func(t *testing.T) {
+			crypto, err := newVoiceCrypto(mode, [32]byte{1, 2, 3})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			t.Run("plain", func(t *testing.T) {
+				packet, err := parseVoicePacket(crypto.seal(header, opus), crypto)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if packet.SSRC != 42 || packet.Sequence != 7 || packet.Timestamp != 960 {
+					t.Errorf("incorrect header values, got %+v", packet)
+				}
+				if !bytes.Equal(packet.Opus, opus) {
+					t.Errorf("expected opus data %v, got %v", opus, packet.Opus)
+				}
+			})
+			t.Run("extension-and-padding", func(t *testing.T) {
+				extended := append([]byte{}, header...)
+				extended[0] |= 0x10 | 0x20 | 0x01 // extension, padding and a single CSRC
+				extended = append(extended, 0, 0, 0, 9)
+
+				// a one word extension, followed by the opus data and 3 bytes of padding
+				extensionHeader := []byte{0xBE, 0xDE, 0x00, 0x01}
+				payload := []byte{0x10, 0xFF, 0x00, 0x00}
+				if crypto.rtpSize() {
+					extended = append(extended, extensionHeader...)
+				} else {
+					payload = append(extensionHeader, payload...)
+				}
+				payload = append(payload, opus...)
+				payload = append(payload, 0, 0, 3)
+
+				packet, err := parseVoicePacket(crypto.seal(extended, payload), crypto)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(packet.Opus, opus) {
+					t.Errorf("expected opus data %v, got %v", opus, packet.Opus)
+				}
+			})
+			t.Run("wrong-key", func(t *testing.T) {
+				other, _ := newVoiceCrypto(mode, [32]byte{})
+				if _, err := parseVoicePacket(crypto.seal(header, opus), other); err == nil {
+					t.Error("expected an error when decrypting with the wrong key")
+				}
+			})
+			t.Run("too-short", func(t *testing.T) {
+				if _, err := parseVoicePacket(header[:8], crypto); err == nil {
+					t.Error("expected an error for a truncated header")
+				}
+				if _, err := parseVoicePacket(header, crypto); err == nil {
+					t.Error("expected an error for a missing payload")
+				}
+			})
+		})
+	}
+}
+
+func TestSelectVoiceMode(t *testing.T) {
+	mode, err := selectVoiceMode([]string{"xsalsa20_poly1305", "aead_xchacha20_poly1305_rtpsize", "unknown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode != voiceModeAEADXChaCha20Poly1305RTPSize {
+		t.Errorf("expected %s, got %s", voiceModeAEADXChaCha20Poly1305RTPSize, mode)
+	}
+
+	if _, err = selectVoiceMode([]string{"unknown"}); err == nil {
+		t.Error("expected an error when no mode is supported")
+	}
+}
+
+func TestVoiceImpl_ssrcs(t *testing.T) {
+	v := &voiceImpl{ssrcs: make(map[uint32]Snowflake)}
+	v.onSpeaking(&gateway.VoiceSpeaking{UserID: 1, SSRC: 10, Speaking: 1})
+	v.onSpeaking(&gateway.VoiceSpeaking{UserID: 2, SSRC: 20, Speaking: 1})
+	v.onClientDisconnect(&gateway.VoiceClientDisconnect{UserID: 1})
+
+	if _, ok := v.ssrcs[10]; ok {
+		t.Error("the ssrc of a disconnected user should be removed")
+	}
+	if v.ssrcs[20] != 2 {
+		t.Errorf("expected ssrc 20 to belong to user 2, got %d", v.ssrcs[20])
+	}
+}
+
+func TestVoiceImpl_events(t *testing.T) {
+	c := New(Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		Cache:        &CacheNop{},
+	})
+	defer close(c.dispatcher.shutdown)
+	go c.demultiplexer(c.dispatcher, c.eventChan)
+
+	speaking := make(chan *VoiceSpeaking)
+	disconnected := make(chan *VoiceClientDisconnect)
+	c.Gateway().VoiceSpeakingChan(speaking)
+	c.Gateway().VoiceClientDisconnectChan(disconnected)
+
+	v := &voiceImpl{guildID: 5, c: c, ssrcs: make(map[uint32]Snowflake)}
+	go v.onSpeaking(&gateway.VoiceSpeaking{UserID: 1, SSRC: 10, Speaking: 1})
+	select {
+	case evt := <-speaking:
+		if evt.GuildID != 5 || evt.UserID != 1 || evt.SSRC != 10 || evt.Speaking != 1 {
+			t.Errorf("incorrect payload, got %+v", evt)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("VoiceSpeaking was not dispatched")
+	}
+
+	go v.onClientDisconnect(&gateway.VoiceClientDisconnect{UserID: 1})
+	select {
+	case evt := <-disconnected:
+		if evt.GuildID != 5 || evt.UserID != 1 || evt.SSRC != 10 {
+			t.Errorf("incorrect payload, got %+v", evt)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("VoiceClientDisconnect was not dispatched")
+	}
+}
+
+func TestVoiceImpl_eventsNotRead(t *testing.T) {
+	c := New(Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		Cache:        &CacheNop{},
+	})
+	defer close(c.shutdownChan)
+
+	// the events are not read, as the reactor is not running
+	v := &voiceImpl{guildID: 5, c: c, ssrcs: make(map[uint32]Snowflake)}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 2*disgordEventBuffer; i++ {
+			v.onSpeaking(&gateway.VoiceSpeaking{UserID: 1, SSRC: 10, Speaking: 1})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the voice connection should not wait for the events to be read")
+	}
+}
+
+// readCounter counts the reads of a connection.
+type readCounter struct {
+	net.Conn
+	reads atomic.Int32
+}
+
+func (c *readCounter) Read(b []byte) (int, error) {
+	c.reads.Inc()
+	return c.Conn.Read(b)
+}
+
+func TestVoiceImpl_receiveAfterReadError(t *testing.T) {
+	c := New(Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		Cache:        &CacheNop{},
+	})
+	crypto, err := newVoiceCrypto(voiceModePreference[0], [32]byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broken, _ := net.Pipe()
+	_ = broken.Close()
+	failing := &readCounter{Conn: broken}
+	v := &voiceImpl{
+		c:           c,
+		udp:         failing,
+		crypto:      crypto,
+		udpReplaced: make(chan struct{}),
+		receive:     make(chan *VoicePacket, 1),
+		close:       make(chan struct{}),
+		ssrcs:       make(map[uint32]Snowflake),
+	}
+	v.ready.Store(true)
+	go v.opusReceiveLoop()
+
+	// the loop waits for the connection to be replaced instead of reading again
+	time.Sleep(50 * time.Millisecond)
+	if reads := failing.reads.Load(); reads != 1 {
+		t.Errorf("expected a single read from the broken connection, got %d", reads)
+	}
+
+	udp, server := net.Pipe()
+	v.udpMu.Lock()
+	v.udp = udp
+	close(v.udpReplaced)
+	v.udpReplaced = make(chan struct{})
+	v.udpMu.Unlock()
+
+	header := make([]byte, rtpHeaderSize)
+	header[0] = 0x80
+	header[1] = 0x78
+	binary.BigEndian.PutUint32(header[8:12], 42)
+	go func() {
+		_, _ = server.Write(crypto.seal(header, []byte{0xF8, 0xFF, 0xFE}))
+	}()
+	select {
+	case packet := <-v.receive:
+		if packet.SSRC != 42 {
+			t.Errorf("expected ssrc 42, got %d", packet.SSRC)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no packets were received from the new connection")
+	}
+
+	v.ready.Store(false)
+	close(v.close)
+	_ = server.Close()
+}
@@ -0,0 +1,288 @@
+package disgord
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+const (
+	// voiceFrameDuration is the duration of the audio in a single Opus frame
+	voiceFrameDuration = 20 * time.Millisecond
+
+	// defaultVoiceJitterBuffer is 100ms of audio
+	defaultVoiceJitterBuffer = 5
+
+	// voiceLateThreshold is how long after its deadline a frame can be sent before it is counted as late
+	voiceLateThreshold = voiceFrameDuration / 4
+)
+
+// opusSilenceFrame is sent opusSilenceFrames times at the end of a transmission, to avoid unintended Opus
+// interpolation with subsequent transmissions.
+var opusSilenceFrame = []byte{0xF8, 0xFF, 0xFE}
+
+const opusSilenceFrames = 5
+
+// VoiceSendStats holds the counters of the audio sent on a voice connection.
+type VoiceSendStats struct {
+	// FramesSent is the number of frames written to the voice server, including inserted silence.
+	FramesSent uint64
+
+	// FramesLate is the number of frames that were sent after their deadline, as the send loop was behind
+	// and had to catch up.
+	FramesLate uint64
+
+	// FramesDropped is the number of frames that were discarded, as the connection was reconnecting or
+	// the frame could not be written.
+	FramesDropped uint64
+
+	// SilenceInserted is the number of silence frames sent because the jitter buffer was empty.
+	SilenceInserted uint64
+
+	// Resyncs is the number of times the send loop fell further behind than the jitter buffer, and skipped
+	// ahead instead of catching up. The skipped time is kept in the RTP timestamps.
+	Resyncs uint64
+}
+
+type voiceSendStats struct {
+	sent    atomic.Uint64
+	late    atomic.Uint64
+	dropped atomic.Uint64
+	silence atomic.Uint64
+	resyncs atomic.Uint64
+}
+
+func (s *voiceSendStats) snapshot() VoiceSendStats {
+	return VoiceSendStats{
+		FramesSent:      s.sent.Load(),
+		FramesLate:      s.late.Load(),
+		FramesDropped:   s.dropped.Load(),
+		SilenceInserted: s.silence.Load(),
+		Resyncs:         s.resyncs.Load(),
+	}
+}
+
+// opusPacer sends the frames of the jitter buffer every 20ms. The deadlines follow the monotonic clock from
+// the first frame of a transmission, such that a delayed frame does not postpone the frames after it. When
+// the buffer runs empty, silence is sent until the frames continue, or until five frames of silence have
+// been sent, which ends the transmission. The RTP timestamp keeps following the clock across silence and
+// pauses.
+type opusPacer struct {
+	frames chan []byte
+	done   <-chan struct{}
+
+	// maxLag is how far the pacer can fall behind before it skips ahead
+	maxLag time.Duration
+
+	// write sends a single RTP packet, and reports whether it was written
+	write func(header, frame []byte) bool
+	stats *voiceSendStats
+
+	header    []byte
+	sequence  uint16
+	timestamp uint32
+	silent    int // the number of consecutive silence frames sent
+
+	mu        sync.Mutex
+	queued    int           // the number of queued frames that have not been sent yet
+	streaming bool          // whether a transmission is ongoing
+	idle      chan struct{} // closed while no frames are queued and no transmission is ongoing
+}
+
+func newOpusPacer(buffer int, done <-chan struct{}, write func(header, frame []byte) bool, stats *voiceSendStats) *opusPacer {
+	// https://discord.com/developers/docs/topics/voice-connections#encrypting-and-sending-voice
+	header := make([]byte, rtpHeaderSize)
+	header[0] = 0x80
+	header[1] = 0x78
+
+	maxLag := time.Duration(buffer) * voiceFrameDuration
+	if maxLag < voiceFrameDuration {
+		maxLag = voiceFrameDuration
+	}
+
+	idle := make(chan struct{})
+	close(idle)
+	return &opusPacer{
+		frames: make(chan []byte, buffer),
+		done:   done,
+		maxLag: maxLag,
+		write:  write,
+		stats:  stats,
+		header: header,
+		idle:   idle,
+	}
+}
+
+// queue adds the frame to the jitter buffer, and blocks while the buffer is full.
+func (p *opusPacer) queue(frame []byte) error {
+	// a select picks a random case when the buffer has room, so the pacer must be checked first
+	select {
+	case <-p.done:
+		return errors.New("attempting to send to a closed voice connection")
+	default:
+	}
+
+	p.setState(1, nil)
+	select {
+	case p.frames <- frame:
+		return nil
+	case <-p.done:
+		p.setState(-1, nil)
+		return errors.New("attempting to send to a closed voice connection")
+	}
+}
+
+// drained returns a channel that is closed once the queued frames have been sent, and the transmission has
+// ended with five frames of silence.
+func (p *opusPacer) drained() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.idle
+}
+
+// drainTimeout is how long it takes to send a full jitter buffer followed by the trailing silence.
+func (p *opusPacer) drainTimeout() time.Duration {
+	return time.Duration(cap(p.frames)+opusSilenceFrames+1) * voiceFrameDuration
+}
+
+// setState adds queued to the number of queued frames, and updates the transmission state when streaming is
+// not nil.
+func (p *opusPacer) setState(queued int, streaming *bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queued += queued
+	if streaming != nil {
+		p.streaming = *streaming
+	}
+
+	select {
+	case <-p.idle:
+		if p.queued > 0 || p.streaming {
+			p.idle = make(chan struct{})
+		}
+	default:
+		if p.queued <= 0 && !p.streaming {
+			close(p.idle)
+		}
+	}
+}
+
+func (p *opusPacer) run() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	if !timer.Stop() {
+		<-timer.C
+	}
+
+	// wait blocks until the given time or until a frame is received, when frames is not nil. It reports
+	// whether the pacer should continue.
+	wait := func(until time.Time, frames <-chan []byte) (frame []byte, ok bool) {
+		delay := time.Until(until)
+		if delay <= 0 {
+			return nil, true
+		}
+		timer.Reset(delay)
+		select {
+		case <-timer.C:
+			return nil, true
+		case frame = <-frames:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			return frame, true
+		case <-p.done:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			return nil, false
+		}
+	}
+
+	var (
+		streaming bool
+		deadline  time.Time // when the current frame should be sent
+	)
+	for {
+		if !streaming {
+			// idle until the next transmission starts
+			var frame []byte
+			select {
+			case frame = <-p.frames:
+			case <-p.done:
+				return
+			}
+
+			now := time.Now()
+			if !deadline.IsZero() {
+				if idle := now.Sub(deadline) - voiceFrameDuration; idle > 0 {
+					p.timestamp += uint32(idle/voiceFrameDuration) * opusFrameLength
+				}
+			}
+			deadline = now
+			streaming = true
+			p.setState(0, &streaming)
+			p.send(frame)
+			p.setState(-1, nil)
+			continue
+		}
+
+		deadline = deadline.Add(voiceFrameDuration)
+		if lag := time.Since(deadline); lag > p.maxLag {
+			skipped := lag / voiceFrameDuration
+			deadline = deadline.Add(skipped * voiceFrameDuration)
+			p.timestamp += uint32(skipped) * opusFrameLength
+			p.stats.resyncs.Inc()
+		} else if _, ok := wait(deadline, nil); !ok {
+			return
+		}
+
+		var frame []byte
+		select {
+		case frame = <-p.frames:
+		default:
+			// give a frame that is slightly delayed a chance before inserting silence
+			var ok bool
+			if frame, ok = wait(deadline.Add(voiceFrameDuration/2), p.frames); !ok {
+				return
+			}
+		}
+
+		if frame == nil {
+			p.stats.silence.Inc()
+			p.send(opusSilenceFrame)
+		} else {
+			if time.Since(deadline) > voiceLateThreshold {
+				p.stats.late.Inc()
+			}
+			p.send(frame)
+			p.setState(-1, nil)
+		}
+		if p.silent >= opusSilenceFrames {
+			streaming = false
+			p.setState(0, &streaming)
+		}
+	}
+}
+
+func (p *opusPacer) send(frame []byte) {
+	if bytes.Equal(frame, opusSilenceFrame) {
+		p.silent++
+	} else {
+		p.silent = 0
+	}
+
+	binary.BigEndian.PutUint16(p.header[2:4], p.sequence)
+	binary.BigEndian.PutUint32(p.header[4:8], p.timestamp)
+	p.sequence++
+	p.timestamp += opusFrameLength
+
+	if p.write(p.header, frame) {
+		p.stats.sent.Inc()
+	} else {
+		p.stats.dropped.Inc()
+	}
+}
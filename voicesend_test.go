@@ -0,0 +1,181 @@
+// +build !integration
+
+package disgord
+
+import (
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+)
+
+type rtpRecord struct {
+	sequence  uint16
+	timestamp uint32
+	frame     string
+}
+
+// pacerRecorder runs an opusPacer and records the packets that are written.
+type pacerRecorder struct {
+	pacer *opusPacer
+	done  chan struct{}
+	stats voiceSendStats
+
+	mu      sync.Mutex
+	packets []rtpRecord
+	onWrite func(n int)
+}
+
+func newPacerRecorder(buffer int) *pacerRecorder {
+	r := &pacerRecorder{
+		done: make(chan struct{}),
+	}
+	r.pacer = newOpusPacer(buffer, r.done, r.write, &r.stats)
+	go r.pacer.run()
+	return r
+}
+
+func (r *pacerRecorder) write(header, frame []byte) bool {
+	r.mu.Lock()
+	r.packets = append(r.packets, rtpRecord{
+		sequence:  binary.BigEndian.Uint16(header[2:4]),
+		timestamp: binary.BigEndian.Uint32(header[4:8]),
+		frame:     string(frame),
+	})
+	n := len(r.packets)
+	r.mu.Unlock()
+
+	if r.onWrite != nil {
+		r.onWrite(n)
+	}
+	return true
+}
+
+// await waits for n packets to be written.
+func (r *pacerRecorder) await(t *testing.T, n int) []rtpRecord {
+	t.Helper()
+	timeout := time.Now().Add(2 * time.Second)
+	for time.Now().Before(timeout) {
+		r.mu.Lock()
+		packets := append([]rtpRecord{}, r.packets...)
+		r.mu.Unlock()
+		if len(packets) >= n {
+			return packets
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected %d packets to be written", n)
+	return nil
+}
+
+func TestOpusPacer_silence(t *testing.T) {
+	r := newPacerRecorder(5)
+	defer close(r.done)
+
+	_ = r.pacer.queue([]byte("a"))
+	_ = r.pacer.queue([]byte("b"))
+	packets := r.await(t, 2+opusSilenceFrames)
+	for i, packet := range packets {
+		if packet.sequence != uint16(i) || packet.timestamp != uint32(i*opusFrameLength) {
+			t.Errorf("expected packet %d to have sequence %d and timestamp %d, got %+v", i, i, i*opusFrameLength, packet)
+		}
+		if expected := []string{"a", "b"}; i < len(expected) && packet.frame != expected[i] {
+			t.Errorf("expected frame %q, got %q", expected[i], packet.frame)
+		} else if i >= len(expected) && packet.frame != string(opusSilenceFrame) {
+			t.Errorf("expected silence to be inserted, got %q", packet.frame)
+		}
+	}
+
+	// the transmission has ended, and the timestamp follows the clock during the pause
+	time.Sleep(5 * voiceFrameDuration)
+	if stats := r.stats.snapshot(); stats.SilenceInserted != opusSilenceFrames || stats.FramesSent != 7 {
+		t.Errorf("expected 5 inserted silence frames and 7 sent frames, got %+v", stats)
+	}
+	_ = r.pacer.queue([]byte("c"))
+	packets = r.await(t, 8)
+	if resumed := packets[7]; resumed.sequence != 7 || resumed.timestamp < 11*opusFrameLength || resumed.timestamp%opusFrameLength != 0 {
+		t.Errorf("expected the timestamp to include the pause, got %+v", resumed)
+	}
+
+	// silence sent by the caller ends the transmission without inserted silence
+	for i := 0; i < opusSilenceFrames; i++ {
+		_ = r.pacer.queue(opusSilenceFrame)
+	}
+	r.await(t, 8+opusSilenceFrames)
+	time.Sleep(3 * voiceFrameDuration)
+	if stats := r.stats.snapshot(); stats.SilenceInserted != opusSilenceFrames {
+		t.Errorf("expected no more silence to be inserted, got %+v", stats)
+	}
+}
+
+func TestOpusPacer_late(t *testing.T) {
+	t.Run("catch-up", func(t *testing.T) {
+		r := newPacerRecorder(5)
+		defer close(r.done)
+		r.onWrite = func(n int) {
+			if n == 1 {
+				time.Sleep(3 * voiceFrameDuration)
+			}
+		}
+
+		start := time.Now()
+		for _, frame := range []string{"1", "2", "3", "4", "5"} {
+			_ = r.pacer.queue([]byte(frame))
+		}
+		packets := r.await(t, 5)
+		if elapsed := time.Since(start); elapsed > 6*voiceFrameDuration {
+			t.Errorf("expected the late frames to catch up, took %s", elapsed)
+		}
+		for i, packet := range packets {
+			if packet.timestamp != uint32(i*opusFrameLength) {
+				t.Errorf("expected packet %d to have timestamp %d, got %d", i, i*opusFrameLength, packet.timestamp)
+			}
+		}
+		if stats := r.stats.snapshot(); stats.FramesLate < 2 || stats.Resyncs != 0 {
+			t.Errorf("expected late frames without a resync, got %+v", stats)
+		}
+	})
+	t.Run("resync", func(t *testing.T) {
+		r := newPacerRecorder(2)
+		defer close(r.done)
+		r.onWrite = func(n int) {
+			if n == 1 {
+				time.Sleep(6 * voiceFrameDuration)
+			}
+		}
+
+		_ = r.pacer.queue([]byte("1"))
+		_ = r.pacer.queue([]byte("2"))
+		packets := r.await(t, 2)
+		if packets[1].sequence != 1 || packets[1].timestamp < 5*opusFrameLength {
+			t.Errorf("expected the skipped time to be kept in the timestamp, got %+v", packets[1])
+		}
+		if stats := r.stats.snapshot(); stats.Resyncs != 1 {
+			t.Errorf("expected a resync, got %+v", stats)
+		}
+	})
+}
+
+func TestOpusPacer_drained(t *testing.T) {
+	r := newPacerRecorder(5)
+	defer close(r.done)
+
+	select {
+	case <-r.pacer.drained():
+	default:
+		t.Fatal("expected an idle pacer to be drained")
+	}
+
+	_ = r.pacer.queue([]byte("a"))
+	_ = r.pacer.queue([]byte("b"))
+	select {
+	case <-r.pacer.drained():
+	case <-time.After(time.Second):
+		t.Fatal("expected the pacer to be drained after the transmission")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if packets := r.packets; len(packets) != 2+opusSilenceFrames {
+		t.Errorf("expected the frames and the trailing silence to be sent before the pacer is drained, got %d packets", len(packets))
+	}
+}